@@ -0,0 +1,117 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var accountDataRoom string
+var accountDataType string
+
+// accountDataCmd represents the get account-data command
+var accountDataCmd = &cobra.Command{
+	Use:   "account-data <mxid>",
+	Short: "Show the account data of a user.",
+	Long: `Lists the global and per-room account data of a user. With --type m.direct or --type m.ignored_user_list
+the content is decoded into a table of direct chats or ignored users.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getAccountData(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "get_account_data_error",
+				"user":  args[0],
+				"error": err,
+			}).Error("Error occurred while getting account data")
+			os.Exit(1)
+		}
+	},
+}
+
+// pushersCmd represents the get pushers command
+var pushersCmd = &cobra.Command{
+	Use:   "pushers <mxid>",
+	Short: "List the pushers of a user.",
+	Long:  `Lists the push gateways and email notification targets registered by a user, one per device or address.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getPushers(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "get_pushers_error",
+				"user":  args[0],
+				"error": err,
+			}).Error("Error occurred while getting pushers")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(accountDataCmd)
+	getCmd.AddCommand(pushersCmd)
+
+	accountDataCmd.Flags().StringVar(&accountDataRoom, "room", "", "Only show the account data of this room")
+	accountDataCmd.Flags().StringVar(&accountDataType, "type", "", "Only show account data of this event type, e.g. m.direct")
+}
+
+func getAccountData(ctx context.Context, config internal.Config, userID string) error {
+	client := synapse.NewSynapseClient(config)
+	data, err := synapse.GetAccountData(ctx, client, logger, userID)
+	if err != nil {
+		return err
+	}
+
+	// Direct chats and ignored users are global account data
+	if accountDataRoom == "" {
+		switch accountDataType {
+		case synapse.AccountDataDirect:
+			rooms, err := synapse.ParseDirectRooms(data.Global[accountDataType])
+			if err != nil {
+				return err
+			}
+			internal.Print(rooms, false)
+			return nil
+		case synapse.AccountDataIgnoredList:
+			users, err := synapse.ParseIgnoredUsers(data.Global[accountDataType])
+			if err != nil {
+				return err
+			}
+			internal.Print(users, false)
+			return nil
+		}
+	}
+
+	internal.Print(data.Entries(accountDataRoom, accountDataType), false)
+	return nil
+}
+
+func getPushers(ctx context.Context, config internal.Config, userID string) error {
+	client := synapse.NewSynapseClient(config)
+	pushers, err := synapse.GetPushers(ctx, client, logger, userID)
+	if err != nil {
+		return err
+	}
+	internal.Print(pushers, false)
+	return nil
+}
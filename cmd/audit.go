@@ -0,0 +1,31 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit Synapse Matrix homeserver resources",
+	Long:  `Audit command allows you to cross-check resources in the Synapse Matrix homeserver, such as the members of a space and of its rooms.`,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// auditSpaceMembersCmd represents the audit space-members command
+var auditSpaceMembersCmd = &cobra.Command{
+	Use:   "space-members <space>",
+	Short: "Compare the members of a space with the members of its child rooms.",
+	Long:  `Reports users in the space who are in none of its rooms and users in child rooms who are not in the space.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := auditSpaceMembers(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "audit_space_members_error",
+				"space": args[0],
+				"error": err,
+			}).Error("Error occurred while auditing space members")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	auditCmd.AddCommand(auditSpaceMembersCmd)
+}
+
+func auditSpaceMembers(ctx context.Context, config internal.Config, spaceID string) error {
+	client := synapse.NewSynapseClient(config)
+	entries, err := synapse.AuditSpaceMembers(ctx, client, logger, spaceID)
+	if err != nil {
+		return err
+	}
+
+	internal.Print(entries, false)
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// backgroundUpdateStartGrace is how long start --wait waits for the started
+// job to show up before an empty status is treated as finished.
+const backgroundUpdateStartGrace = 30 * time.Second
+
+var backgroundUpdatesWait bool
+var backgroundUpdatesInterval time.Duration
+
+// backgroundUpdatesCmd represents the background-updates command
+var backgroundUpdatesCmd = &cobra.Command{
+	Use:   "background-updates",
+	Short: "Monitor and control database background updates",
+	Long:  `Background-updates command allows you to follow the database migrations Synapse runs in the background, typically after an upgrade, and to pause, resume or start them.`,
+}
+
+// backgroundUpdatesStatusCmd represents the background-updates status command
+var backgroundUpdatesStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the running background updates.",
+	Long:  `Shows whether background updates are enabled and which ones are running. With --wait, polls until all updates finish.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := backgroundUpdatesStatus(cmd.Context(), config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "background_updates_status_error",
+				"error": err,
+			}).Error("Error occurred while getting background updates status")
+			os.Exit(1)
+		}
+	},
+}
+
+// backgroundUpdatesEnableCmd represents the background-updates enable command
+var backgroundUpdatesEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Resume background updates.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := setBackgroundUpdatesEnabled(cmd.Context(), config, true)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "background_updates_enable_error",
+				"error": err,
+			}).Error("Error occurred while enabling background updates")
+			os.Exit(1)
+		}
+	},
+}
+
+// backgroundUpdatesDisableCmd represents the background-updates disable command
+var backgroundUpdatesDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Pause background updates.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := setBackgroundUpdatesEnabled(cmd.Context(), config, false)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "background_updates_disable_error",
+				"error": err,
+			}).Error("Error occurred while disabling background updates")
+			os.Exit(1)
+		}
+	},
+}
+
+// backgroundUpdatesStartCmd represents the background-updates start command
+var backgroundUpdatesStartCmd = &cobra.Command{
+	Use:   "start <job>",
+	Short: "Start a background update job.",
+	Long: `Starts a background update job, e.g. populate_stats_process_rooms or regenerate_directory. With --wait, polls until all updates finish,
+waiting up to 30 seconds for the updater to pick up the job.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := startBackgroundUpdate(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "background_updates_start_error",
+				"job":   args[0],
+				"error": err,
+			}).Error("Error occurred while starting background update job")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backgroundUpdatesCmd)
+	backgroundUpdatesCmd.AddCommand(backgroundUpdatesStatusCmd)
+	backgroundUpdatesCmd.AddCommand(backgroundUpdatesEnableCmd)
+	backgroundUpdatesCmd.AddCommand(backgroundUpdatesDisableCmd)
+	backgroundUpdatesCmd.AddCommand(backgroundUpdatesStartCmd)
+
+	for _, c := range []*cobra.Command{backgroundUpdatesStatusCmd, backgroundUpdatesStartCmd} {
+		c.Flags().BoolVar(&backgroundUpdatesWait, "wait", false, "Poll until all background updates finish")
+		c.Flags().DurationVar(&backgroundUpdatesInterval, "interval", 10*time.Second, "Polling interval used with --wait")
+	}
+}
+
+func backgroundUpdatesStatus(ctx context.Context, config internal.Config) error {
+	client := synapse.NewSynapseClient(config)
+	status, err := synapse.GetBackgroundUpdatesStatus(ctx, client, logger)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Background updates enabled: %t\n", status.Enabled)
+	internal.Print(status.Current, false)
+	if backgroundUpdatesWait {
+		return waitForBackgroundUpdates(ctx, client, 0)
+	}
+	return nil
+}
+
+func setBackgroundUpdatesEnabled(ctx context.Context, config internal.Config, enabled bool) error {
+	client := synapse.NewSynapseClient(config)
+	state, err := synapse.SetBackgroundUpdatesEnabled(ctx, client, logger, enabled)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Background updates enabled: %t\n", state)
+	return nil
+}
+
+func startBackgroundUpdate(ctx context.Context, config internal.Config, job string) error {
+	client := synapse.NewSynapseClient(config)
+	if err := synapse.StartBackgroundUpdateJob(ctx, client, logger, job); err != nil {
+		return err
+	}
+	fmt.Printf("Started background update job %s\n", job)
+	if backgroundUpdatesWait {
+		return waitForBackgroundUpdates(ctx, client, backgroundUpdateStartGrace)
+	}
+	return nil
+}
+
+func waitForBackgroundUpdates(ctx context.Context, client synapse.SynapseClientInterface, grace time.Duration) error {
+	if backgroundUpdatesInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if err := synapse.WaitForBackgroundUpdates(ctx, client, logger, backgroundUpdatesInterval, grace); err != nil {
+		return err
+	}
+	fmt.Println("All background updates finished")
+	return nil
+}
@@ -0,0 +1,35 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// confirm asks the user to type "yes" before a destructive operation.
+func confirm(in io.Reader, out io.Writer, prompt string) bool {
+	if _, err := fmt.Fprintf(out, "%s Type 'yes' to continue: ", prompt); err != nil {
+		return false
+	}
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	return strings.TrimSpace(answer) == "yes"
+}
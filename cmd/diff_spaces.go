@@ -0,0 +1,79 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// diffSpacesCmd represents the diff spaces command
+var diffSpacesCmd = &cobra.Command{
+	Use:   "spaces <old.json> <new.json>",
+	Short: "Report changes between two spaces snapshots.",
+	Long:  `Reports spaces added and removed, renames, membership deltas and child room changes between two snapshots taken with "get spaces -o json".`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := diffSpaces(args[0], args[1])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "diff_spaces_error",
+				"error": err,
+			}).Error("Error occurred while comparing spaces snapshots")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	diffCmd.AddCommand(diffSpacesCmd)
+}
+
+func diffSpaces(oldPath, newPath string) error {
+	before, err := loadSpacesSnapshot(oldPath)
+	if err != nil {
+		return err
+	}
+	after, err := loadSpacesSnapshot(newPath)
+	if err != nil {
+		return err
+	}
+
+	internal.Print(synapse.DiffSpaces(before, after), false)
+	return nil
+}
+
+func loadSpacesSnapshot(path string) ([]synapse.Space, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		cerr := f.Close()
+		if cerr != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "close_snapshot_failed",
+				"file":  path,
+				"error": cerr,
+			}).Warn("Failed to close snapshot file")
+		}
+	}()
+	return synapse.LoadSpaces(f)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// featuresCmd represents the features command
+var featuresCmd = &cobra.Command{
+	Use:   "features",
+	Short: "Manage per-user experimental features",
+	Long:  `Features command allows you to enable and disable experimental (MSC) features for individual users of the Synapse Matrix homeserver.`,
+}
+
+// featuresGetCmd represents the features get command
+var featuresGetCmd = &cobra.Command{
+	Use:   "get <mxid>",
+	Short: "Show the experimental features of a user.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getFeatures(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "get_features_error",
+				"user":  args[0],
+				"error": err,
+			}).Error("Error occurred while getting experimental features")
+			os.Exit(1)
+		}
+	},
+}
+
+// featuresSetCmd represents the features set command
+var featuresSetCmd = &cobra.Command{
+	Use:   "set <mxid|@file> <feature>=<true|false>...",
+	Short: "Enable or disable experimental features for one user or many.",
+	Long: `Enables or disables experimental features, e.g. msc3881=true. The first argument is a user ID or @path
+to a file with one user ID per line, so the same set of features can be applied to a whole beta group.
+Features that are not given keep their current state.`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := setFeatures(cmd.Context(), config, args[0], args[1:])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "set_features_error",
+				"error": err,
+			}).Error("Error occurred while setting experimental features")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(featuresCmd)
+	featuresCmd.AddCommand(featuresGetCmd)
+	featuresCmd.AddCommand(featuresSetCmd)
+}
+
+func getFeatures(ctx context.Context, config internal.Config, userID string) error {
+	client := synapse.NewSynapseClient(config)
+	features, err := synapse.GetExperimentalFeatures(ctx, client, userID)
+	if err != nil {
+		return err
+	}
+	internal.Print(features, false)
+	return nil
+}
+
+func setFeatures(ctx context.Context, config internal.Config, target string, toggles []string) error {
+	features, err := synapse.ParseFeatureToggles(toggles)
+	if err != nil {
+		return err
+	}
+
+	var users []string
+	switch {
+	case isUserID(target):
+		users = []string{target}
+	case strings.HasPrefix(target, "@"):
+		users, err = readUserList(strings.TrimPrefix(target, "@"))
+	default:
+		err = fmt.Errorf("invalid user %q: expected a user ID or @path to a file", target)
+	}
+	if err != nil {
+		return err
+	}
+
+	client := synapse.NewSynapseClient(config)
+	applied, err := synapse.SetExperimentalFeatures(ctx, client, logger, users, features)
+	fmt.Printf("Updated experimental features of %d of %d users\n", applied, len(users))
+	return err
+}
@@ -0,0 +1,145 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var destinationsSearch string
+var destinationsFailing bool
+
+// federationCmd represents the federation command
+var federationCmd = &cobra.Command{
+	Use:   "federation",
+	Short: "Inspect and reset federation with remote servers",
+	Long:  `Federation command allows you to inspect the connection state towards remote servers and reset their retry backoff.`,
+}
+
+// federationDestinationsCmd represents the federation destinations command
+var federationDestinationsCmd = &cobra.Command{
+	Use:   "destinations",
+	Short: "List federation destinations with failure timestamps and retry intervals.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := listDestinations(cmd.Context(), config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "list_destinations_error",
+				"error": err,
+			}).Error("Error occurred while listing federation destinations")
+			os.Exit(1)
+		}
+	},
+}
+
+// federationDestinationCmd represents the federation destination command
+var federationDestinationCmd = &cobra.Command{
+	Use:   "destination <server>",
+	Short: "Show the federation state of a server and the rooms shared with it.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := showDestination(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event":       "show_destination_error",
+				"destination": args[0],
+				"error":       err,
+			}).Error("Error occurred while showing federation destination")
+			os.Exit(1)
+		}
+	},
+}
+
+// federationResetCmd represents the federation reset command
+var federationResetCmd = &cobra.Command{
+	Use:   "reset <server>",
+	Short: "Reset the connection backoff of a server so it is retried immediately.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := resetDestination(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event":       "reset_destination_error",
+				"destination": args[0],
+				"error":       err,
+			}).Error("Error occurred while resetting federation destination")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(federationCmd)
+	federationCmd.AddCommand(federationDestinationsCmd)
+	federationCmd.AddCommand(federationDestinationCmd)
+	federationCmd.AddCommand(federationResetCmd)
+
+	federationDestinationsCmd.Flags().StringVar(&destinationsSearch, "search", "", "Only list destinations whose name contains this term")
+	federationDestinationsCmd.Flags().BoolVar(&destinationsFailing, "failing", false, "Only list destinations that are currently failing")
+}
+
+func listDestinations(ctx context.Context, config internal.Config) error {
+	client := synapse.NewSynapseClient(config)
+	destinations, err := synapse.ListDestinations(ctx, client, logger, destinationsSearch)
+	if err != nil {
+		return err
+	}
+
+	if destinationsFailing {
+		failing := make([]synapse.Destination, 0)
+		for _, d := range destinations {
+			if d.Failing() {
+				failing = append(failing, d)
+			}
+		}
+		destinations = failing
+	}
+
+	internal.Print(destinations, false)
+	return nil
+}
+
+func showDestination(ctx context.Context, config internal.Config, server string) error {
+	client := synapse.NewSynapseClient(config)
+	destination, err := synapse.GetDestination(ctx, client, server)
+	if err != nil {
+		return err
+	}
+	rooms, err := synapse.GetDestinationRooms(ctx, client, logger, server)
+	if err != nil {
+		return err
+	}
+
+	internal.Print([]synapse.Destination{destination}, false)
+	internal.Print(rooms, false)
+	return nil
+}
+
+func resetDestination(ctx context.Context, config internal.Config, server string) error {
+	client := synapse.NewSynapseClient(config)
+	if err := synapse.ResetDestination(ctx, client, logger, server); err != nil {
+		return err
+	}
+	fmt.Printf("Reset connection backoff for %s\n", server)
+	return nil
+}
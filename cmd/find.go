@@ -0,0 +1,101 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var findEmail string
+var findMsisdn string
+var findAuthProvider string
+var findExternalID string
+
+// findCmd represents the find command
+var findCmd = &cobra.Command{
+	Use:   "find",
+	Short: "Find resources",
+	Long:  `Find command allows you to look up resources of the Synapse Matrix homeserver by identifiers other than their Matrix ID.`,
+}
+
+// findUserCmd represents the find user command
+var findUserCmd = &cobra.Command{
+	Use:   "user (--email <address> | --msisdn <number> | --auth-provider <id> --external-id <id>)",
+	Short: "Find a user by email, phone number or SSO external ID.",
+	Long: `Finds the account bound to an email address or phone number, or linked to an external ID of an SSO
+auth provider (the idp_id from the homeserver configuration, e.g. oidc), and shows its details.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := findUser(cmd.Context(), config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "find_user_error",
+				"error": err,
+			}).Error("Error occurred while finding user")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(findCmd)
+	findCmd.AddCommand(findUserCmd)
+
+	findUserCmd.Flags().StringVar(&findEmail, "email", "", "Email address bound to the account")
+	findUserCmd.Flags().StringVar(&findMsisdn, "msisdn", "", "Phone number bound to the account, in international format without +")
+	findUserCmd.Flags().StringVar(&findAuthProvider, "auth-provider", "", "ID of the SSO auth provider")
+	findUserCmd.Flags().StringVar(&findExternalID, "external-id", "", "ID of the user at the SSO auth provider")
+	findUserCmd.MarkFlagsRequiredTogether("auth-provider", "external-id")
+	findUserCmd.MarkFlagsMutuallyExclusive("email", "msisdn", "auth-provider")
+	findUserCmd.MarkFlagsOneRequired("email", "msisdn", "auth-provider")
+}
+
+func findUser(ctx context.Context, config internal.Config) error {
+	client := synapse.NewSynapseClient(config)
+
+	var userID, lookup string
+	var err error
+	switch {
+	case findEmail != "":
+		lookup = "email " + findEmail
+		userID, err = synapse.FindUserByThreepid(ctx, client, logger, "email", findEmail)
+	case findMsisdn != "":
+		lookup = "phone number " + findMsisdn
+		userID, err = synapse.FindUserByThreepid(ctx, client, logger, "msisdn", findMsisdn)
+	default:
+		lookup = fmt.Sprintf("external ID %s of %s", findExternalID, findAuthProvider)
+		userID, err = synapse.FindUserByExternalID(ctx, client, logger, findAuthProvider, findExternalID)
+	}
+	if synapse.IsNotFound(err) {
+		return fmt.Errorf("no user found for %s", lookup)
+	}
+	if err != nil {
+		return err
+	}
+
+	user, err := synapse.GetUser(ctx, client, userID)
+	if err != nil {
+		return err
+	}
+	internal.Print([]synapse.User{user}, false)
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// lintSpacesCmd represents the lint spaces command
+var lintSpacesCmd = &cobra.Command{
+	Use:   "spaces",
+	Short: "Report broken or inconsistent space hierarchies.",
+	Long: `Walks the hierarchy below every public space and reports dangling children, children
+on other servers that this server never joined, cycles, children without via, missing
+reciprocal m.space.parent events, children with stricter join rules than their parent
+and spaces with zero children.
+Exits with code 1 when issues are found.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		issues, err := lintSpaces(cmd.Context(), config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "lint_spaces_error",
+				"error": err,
+			}).Error("Error occurred while linting spaces")
+			os.Exit(1)
+		}
+		if issues > 0 {
+			logger.WithFields(logrus.Fields{
+				"event":  "lint_spaces_issues",
+				"issues": issues,
+			}).Error("Space hierarchy issues found")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	lintCmd.AddCommand(lintSpacesCmd)
+}
+
+func lintSpaces(ctx context.Context, config internal.Config) (int, error) {
+	client := synapse.NewSynapseClient(config)
+	spaces, err := synapse.GetSpaces(client, logger, synapse.SpacesQuery{})
+	if err != nil {
+		return 0, err
+	}
+
+	issues, err := synapse.LintSpaces(ctx, client, logger, spaces)
+	if err != nil {
+		return 0, err
+	}
+
+	internal.Print(issues, false)
+	return len(issues), nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var backupDir string
+
+// mediaBackupCmd represents the media backup command
+var mediaBackupCmd = &cobra.Command{
+	Use:   "backup <room>",
+	Short: "Back up the media referenced by a room to a local directory.",
+	Long: `Downloads every media item referenced by a room into --dir, verifies the downloaded sizes
+and writes a manifest.json mapping the mxc URIs to the saved files.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := backupRoomMedia(cmd.Context(), config, args[0], backupDir)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "media_backup_error",
+				"room":  args[0],
+				"error": err,
+			}).Error("Error occurred while backing up room media")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	mediaCmd.AddCommand(mediaBackupCmd)
+
+	mediaBackupCmd.Flags().StringVar(&backupDir, "dir", "", "Directory to write the media and manifest to")
+	if err := mediaBackupCmd.MarkFlagRequired("dir"); err != nil {
+		panic(err)
+	}
+}
+
+func backupRoomMedia(ctx context.Context, config internal.Config, roomID string, dir string) error {
+	client := synapse.NewSynapseClient(config)
+	manifest, err := synapse.BackupRoomMedia(ctx, client, logger, roomID, dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Backed up %d media items to %s\n", len(manifest.Media), filepath.Join(dir, synapse.ManifestFile))
+	if len(manifest.Missing) > 0 {
+		logger.WithFields(logrus.Fields{
+			"event":   "media_backup_missing",
+			"missing": len(manifest.Missing),
+		}).Warn("Some media referenced by the room no longer exists, see the manifest")
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var deleteLocalBefore string
+var deleteLocalSizeGT string
+var deleteLocalKeepProfiles bool
+var deleteYes bool
+
+var errNotConfirmed = errors.New("operation not confirmed")
+
+// mediaDeleteLocalCmd represents the media delete-local command
+var mediaDeleteLocalCmd = &cobra.Command{
+	Use:   "delete-local",
+	Short: "Delete local media by age and size.",
+	Long:  `Deletes local media last accessed before the cutoff given by --before (e.g. 180d), optionally only media larger than --size-gt (e.g. 10MB).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := deleteLocalMedia(cmd.Context(), config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "delete_local_media_error",
+				"error": err,
+			}).Error("Error occurred while deleting local media")
+			os.Exit(1)
+		}
+	},
+}
+
+// mediaDeleteCmd represents the media delete command
+var mediaDeleteCmd = &cobra.Command{
+	Use:   "delete <server>/<media_id> | mxc://<server>/<media_id>",
+	Short: "Delete a single local media item.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := deleteMedia(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "delete_media_error",
+				"media": args[0],
+				"error": err,
+			}).Error("Error occurred while deleting media")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	mediaCmd.AddCommand(mediaDeleteLocalCmd)
+	mediaCmd.AddCommand(mediaDeleteCmd)
+
+	mediaDeleteLocalCmd.Flags().StringVar(&deleteLocalBefore, "before", "", "Delete media last accessed before this age (e.g. 180d)")
+	mediaDeleteLocalCmd.Flags().StringVar(&deleteLocalSizeGT, "size-gt", "", "Only delete media larger than this size (e.g. 10MB)")
+	mediaDeleteLocalCmd.Flags().BoolVar(&deleteLocalKeepProfiles, "keep-profiles", false, "Keep media used as user or room avatars")
+	mediaDeleteLocalCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Skip the confirmation prompt")
+	mediaDeleteCmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Skip the confirmation prompt")
+	if err := mediaDeleteLocalCmd.MarkFlagRequired("before"); err != nil {
+		panic(err)
+	}
+}
+
+func deleteLocalMedia(ctx context.Context, config internal.Config) error {
+	age, err := internal.ParseAge(deleteLocalBefore)
+	if err != nil {
+		return err
+	}
+	query := synapse.DeleteLocalMediaQuery{Before: time.Now().Add(-age), KeepProfiles: deleteLocalKeepProfiles}
+	if deleteLocalSizeGT != "" {
+		query.SizeGT, err = internal.ParseSize(deleteLocalSizeGT)
+		if err != nil {
+			return err
+		}
+	}
+
+	prompt := fmt.Sprintf("This will permanently delete local media last accessed before %s", query.Before.Format(time.RFC3339))
+	if query.SizeGT > 0 {
+		prompt += fmt.Sprintf(" and larger than %s", internal.HumanBytes(query.SizeGT))
+	}
+	if !query.KeepProfiles {
+		prompt += ", including user and room avatars"
+	}
+	if !deleteYes && !confirm(os.Stdin, os.Stdout, prompt+".") {
+		return errNotConfirmed
+	}
+
+	client := synapse.NewSynapseClient(config)
+	total, err := synapse.DeleteLocalMedia(ctx, client, logger, query)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %d local media items\n", total)
+	return nil
+}
+
+func deleteMedia(ctx context.Context, config internal.Config, media string) error {
+	server, mediaID, err := synapse.ParseMediaID(media)
+	if err != nil {
+		return err
+	}
+	if !deleteYes && !confirm(os.Stdin, os.Stdout, fmt.Sprintf("This will permanently delete mxc://%s/%s.", server, mediaID)) {
+		return errNotConfirmed
+	}
+
+	client := synapse.NewSynapseClient(config)
+	total, err := synapse.DeleteMedia(ctx, client, logger, server, mediaID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %d media items\n", total)
+	return nil
+}
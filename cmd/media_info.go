@@ -0,0 +1,65 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// mediaInfoCmd represents the media-info command
+var mediaInfoCmd = &cobra.Command{
+	Use:   "media-info <mxc://server/media_id>",
+	Short: "Retrieve details of a single media item.",
+	Long:  `Shows whether the media is local or remote, its size, content type, uploader and quarantine status.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getMediaInfo(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "get_media_info_error",
+				"media": args[0],
+				"error": err,
+			}).Error("Error occurred while getting media info")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(mediaInfoCmd)
+}
+
+func getMediaInfo(ctx context.Context, config internal.Config, media string) error {
+	server, mediaID, err := synapse.ParseMediaID(media)
+	if err != nil {
+		return err
+	}
+
+	client := synapse.NewSynapseClient(config)
+	info, err := synapse.GetMediaInfo(ctx, client, logger, server, mediaID)
+	if err != nil {
+		return err
+	}
+
+	internal.Print([]synapse.MediaInfo{info}, false)
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var purgeRemoteBefore string
+
+// mediaPurgeRemoteCmd represents the media purge-remote command
+var mediaPurgeRemoteCmd = &cobra.Command{
+	Use:   "purge-remote",
+	Short: "Purge cached remote media older than a cutoff.",
+	Long: `Deletes cached copies of remote media that were last accessed before the cutoff given by --before (e.g. 30d, 12h).
+Synapse does not report the size of the remote media cache, so the number of purged items is only known afterwards.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := purgeRemoteMedia(cmd.Context(), config, purgeRemoteBefore)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "purge_remote_media_error",
+				"error": err,
+			}).Error("Error occurred while purging remote media cache")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	mediaCmd.AddCommand(mediaPurgeRemoteCmd)
+
+	mediaPurgeRemoteCmd.Flags().StringVar(&purgeRemoteBefore, "before", "", "Purge remote media last accessed before this age (e.g. 30d, 12h)")
+	if err := mediaPurgeRemoteCmd.MarkFlagRequired("before"); err != nil {
+		panic(err)
+	}
+}
+
+func purgeRemoteMedia(ctx context.Context, config internal.Config, before string) error {
+	age, err := internal.ParseAge(before)
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().Add(-age)
+
+	client := synapse.NewSynapseClient(config)
+	deleted, err := synapse.PurgeRemoteMediaCache(ctx, client, logger, cutoff)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %d remote media items last accessed before %s\n", deleted, cutoff.Format(time.RFC3339))
+	return nil
+}
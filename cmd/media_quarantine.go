@@ -0,0 +1,149 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var quarantineRoom string
+var quarantineUser string
+var quarantineProtect []string
+
+// mediaQuarantineCmd represents the media quarantine command
+var mediaQuarantineCmd = &cobra.Command{
+	Use:   "quarantine [<server>/<media_id> | mxc://<server>/<media_id>]",
+	Short: "Quarantine a media item, all media in a room or all media of a user.",
+	Long: `Quarantines a single media item, all media in a room (--room) or all media uploaded by a user (--user).
+Media listed in --protect is protected first so that it is exempt from the quarantine.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := quarantineMedia(cmd.Context(), config, args)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "quarantine_media_error",
+				"error": err,
+			}).Error("Error occurred while quarantining media")
+			os.Exit(1)
+		}
+	},
+}
+
+// mediaUnquarantineCmd represents the media unquarantine command
+var mediaUnquarantineCmd = &cobra.Command{
+	Use:   "unquarantine <server>/<media_id> | mxc://<server>/<media_id>",
+	Short: "Remove a media item from quarantine.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := unquarantineMedia(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "unquarantine_media_error",
+				"error": err,
+			}).Error("Error occurred while removing media from quarantine")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	mediaCmd.AddCommand(mediaQuarantineCmd)
+	mediaCmd.AddCommand(mediaUnquarantineCmd)
+
+	mediaQuarantineCmd.Flags().StringVar(&quarantineRoom, "room", "", "Quarantine all media in this room")
+	mediaQuarantineCmd.Flags().StringVar(&quarantineUser, "user", "", "Quarantine all media uploaded by this user")
+	mediaQuarantineCmd.Flags().StringSliceVar(&quarantineProtect, "protect", nil, "Local media IDs to protect from quarantine (comma separated or repeated)")
+	mediaQuarantineCmd.MarkFlagsMutuallyExclusive("room", "user")
+}
+
+func quarantineMedia(ctx context.Context, config internal.Config, args []string) error {
+	targets := len(args)
+	if quarantineRoom != "" {
+		targets++
+	}
+	if quarantineUser != "" {
+		targets++
+	}
+	if targets != 1 {
+		return fmt.Errorf("exactly one of a media ID, --room or --user must be provided")
+	}
+
+	client := synapse.NewSynapseClient(config)
+	for _, media := range quarantineProtect {
+		// The protect endpoint only takes the media ID, so drop the server part if given
+		if strings.Contains(media, "/") {
+			_, mediaID, err := synapse.ParseMediaID(media)
+			if err != nil {
+				return err
+			}
+			media = mediaID
+		}
+		if err := synapse.ProtectMedia(ctx, client, logger, media); err != nil {
+			return err
+		}
+		logger.WithFields(logrus.Fields{
+			"event": "media_protected",
+			"media": media,
+		}).Info("Media protected from quarantine")
+	}
+
+	switch {
+	case quarantineRoom != "":
+		count, err := synapse.QuarantineRoomMedia(ctx, client, logger, quarantineRoom)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Quarantined %d media items in room %s\n", count, quarantineRoom)
+	case quarantineUser != "":
+		count, err := synapse.QuarantineUserMedia(ctx, client, logger, quarantineUser)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Quarantined %d media items of user %s\n", count, quarantineUser)
+	default:
+		server, mediaID, err := synapse.ParseMediaID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := synapse.QuarantineMedia(ctx, client, logger, server, mediaID); err != nil {
+			return err
+		}
+		fmt.Printf("Quarantined mxc://%s/%s\n", server, mediaID)
+	}
+	return nil
+}
+
+func unquarantineMedia(ctx context.Context, config internal.Config, media string) error {
+	server, mediaID, err := synapse.ParseMediaID(media)
+	if err != nil {
+		return err
+	}
+
+	client := synapse.NewSynapseClient(config)
+	if err := synapse.UnquarantineMedia(ctx, client, logger, server, mediaID); err != nil {
+		return err
+	}
+	fmt.Printf("Removed mxc://%s/%s from quarantine\n", server, mediaID)
+	return nil
+}
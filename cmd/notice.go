@@ -0,0 +1,176 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var noticeTo string
+var noticeAllLocal bool
+var noticeConcurrency int
+var noticeProgress string
+var noticeForce bool
+
+// noticeCmd represents the notice command
+var noticeCmd = &cobra.Command{
+	Use:   "notice",
+	Short: "Send server notices",
+	Long:  `Notice command allows you to send server notices to users of the Synapse Matrix homeserver.`,
+}
+
+// noticeSendCmd represents the notice send command
+var noticeSendCmd = &cobra.Command{
+	Use:   "send (--to <mxid|@file> | --all-local) <message>",
+	Short: "Send a markdown formatted server notice to one user or many.",
+	Long: `Sends a server notice written in markdown. --to takes a user ID or @path to a file with one user ID per line;
+--all-local sends to every active local user. With --progress, users that received the notice are recorded
+in the given file and skipped when the command is run again, so an interrupted broadcast can be resumed.
+A progress file written for a different message is refused unless --force is given, which starts it over.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := sendNotice(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "send_notice_error",
+				"error": err,
+			}).Error("Error occurred while sending server notice")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(noticeCmd)
+	noticeCmd.AddCommand(noticeSendCmd)
+
+	noticeSendCmd.Flags().StringVar(&noticeTo, "to", "", "Recipient user ID, or @path to a file with one user ID per line")
+	noticeSendCmd.Flags().BoolVar(&noticeAllLocal, "all-local", false, "Send to every active local user")
+	noticeSendCmd.Flags().IntVar(&noticeConcurrency, "concurrency", 5, "Maximum number of notices sent at the same time")
+	noticeSendCmd.Flags().StringVar(&noticeProgress, "progress", "", "File recording delivered notices, used to resume an interrupted broadcast")
+	noticeSendCmd.Flags().BoolVar(&noticeForce, "force", false, "Discard a progress file written for a different message and start over")
+	noticeSendCmd.MarkFlagsMutuallyExclusive("to", "all-local")
+	noticeSendCmd.MarkFlagsOneRequired("to", "all-local")
+}
+
+func sendNotice(ctx context.Context, config internal.Config, message string) error {
+	client := synapse.NewSynapseClient(config)
+
+	var users []string
+	var err error
+	switch {
+	case noticeAllLocal:
+		users, err = synapse.ListLocalUsers(ctx, client, logger)
+	case isUserID(noticeTo):
+		users = []string{noticeTo}
+	case strings.HasPrefix(noticeTo, "@"):
+		users, err = readUserList(strings.TrimPrefix(noticeTo, "@"))
+	default:
+		err = fmt.Errorf("invalid recipient %q: expected a user ID or @path to a file", noticeTo)
+	}
+	if err != nil {
+		return err
+	}
+
+	notice := synapse.NewNotice(message)
+	var progress *internal.ProgressRecord
+	if noticeProgress != "" {
+		progress, err = internal.OpenProgressRecord(noticeProgress, notice.Fingerprint())
+		if errors.Is(err, internal.ErrProgressMismatch) && noticeForce {
+			logger.WithFields(logrus.Fields{
+				"event": "progress_discarded",
+				"file":  noticeProgress,
+			}).Warn("Discarding progress recorded for a different message")
+			if err := os.Remove(noticeProgress); err != nil {
+				return err
+			}
+			progress, err = internal.OpenProgressRecord(noticeProgress, notice.Fingerprint())
+		}
+		if errors.Is(err, internal.ErrProgressMismatch) {
+			return fmt.Errorf("%w; use another --progress file or --force to start over", err)
+		}
+		if err != nil {
+			return err
+		}
+		defer func() {
+			cerr := progress.Close()
+			if cerr != nil {
+				logger.WithFields(logrus.Fields{
+					"event": "close_progress_failed",
+					"file":  noticeProgress,
+					"error": cerr,
+				}).Warn("Failed to close progress record")
+			}
+		}()
+	}
+
+	result, err := synapse.BroadcastServerNotice(ctx, client, logger, users, notice, noticeConcurrency, progress)
+	fmt.Printf("Sent %d notices, skipped %d already delivered, %d rejected\n", result.Sent, result.Skipped, len(result.Failed))
+	for _, user := range result.Failed {
+		fmt.Printf("Rejected: %s\n", user)
+	}
+	if err != nil && noticeProgress != "" {
+		logger.WithFields(logrus.Fields{
+			"event": "send_notice_interrupted",
+			"file":  noticeProgress,
+		}).Info("Run the same command again to resume the broadcast")
+	}
+	return err
+}
+
+// isUserID reports whether s looks like a Matrix user ID rather than an @file reference.
+func isUserID(s string) bool {
+	return strings.HasPrefix(s, "@") && strings.Contains(s, ":") && !strings.ContainsAny(s, "/\\")
+}
+
+// readUserList reads one user ID per line, ignoring blank lines and # comments.
+func readUserList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		cerr := f.Close()
+		if cerr != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "close_user_list_failed",
+				"file":  path,
+				"error": cerr,
+			}).Warn("Failed to close user list")
+		}
+	}()
+
+	users := make([]string, 0)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		users = append(users, line)
+	}
+	return users, scanner.Err()
+}
@@ -0,0 +1,115 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var redactRooms []string
+var redactReason string
+var redactLimit int
+var redactInterval time.Duration
+var redactYes bool
+
+// redactCmd represents the redact command
+var redactCmd = &cobra.Command{
+	Use:   "redact",
+	Short: "Redact events",
+	Long:  `Redact command allows you to remove the content of events from the Synapse Matrix homeserver.`,
+}
+
+// redactUserCmd represents the redact user command
+var redactUserCmd = &cobra.Command{
+	Use:   "user <mxid>",
+	Short: "Redact every event sent by a user.",
+	Long: `Schedules the redaction of the events sent by a user, in every room they are a member of or only in
+the rooms given with --rooms, then polls until the task finishes and lists the events that could not
+be redacted. Asks for confirmation unless --yes is given.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := redactUser(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "redact_user_error",
+				"user":  args[0],
+				"error": err,
+			}).Error("Error occurred while redacting user events")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(redactCmd)
+	redactCmd.AddCommand(redactUserCmd)
+
+	redactUserCmd.Flags().StringSliceVar(&redactRooms, "rooms", nil, "Only redact events in these rooms (comma separated room IDs)")
+	redactUserCmd.Flags().StringVar(&redactReason, "reason", "", "Reason attached to the redactions")
+	redactUserCmd.Flags().IntVar(&redactLimit, "limit", 0, "Maximum number of events redacted per room (default: server default)")
+	redactUserCmd.Flags().DurationVar(&redactInterval, "interval", 5*time.Second, "Polling interval while the redaction runs")
+	redactUserCmd.Flags().BoolVarP(&redactYes, "yes", "y", false, "Skip the confirmation prompt")
+}
+
+func redactUser(ctx context.Context, config internal.Config, userID string) error {
+	if redactInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	scope := "in every room they are a member of"
+	if len(redactRooms) > 0 {
+		scope = "in " + strings.Join(redactRooms, ", ")
+	}
+	if !redactYes && !confirm(os.Stdin, os.Stdout, fmt.Sprintf("This will redact every event sent by %s %s.", userID, scope)) {
+		return errNotConfirmed
+	}
+
+	client := synapse.NewSynapseClient(config)
+	redactID, err := synapse.RedactUserEvents(ctx, client, logger, userID, synapse.RedactUserQuery{
+		Rooms:  redactRooms,
+		Reason: redactReason,
+		Limit:  redactLimit,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Scheduled redaction %s\n", redactID)
+
+	status, err := synapse.WaitForRedaction(ctx, client, logger, redactID, redactInterval)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Redaction %s finished with status %s\n", redactID, status.Status)
+	failed := status.Failed()
+	if len(failed) > 0 {
+		internal.Print(failed, false)
+	}
+	if status.Status == synapse.RedactFailed {
+		return fmt.Errorf("redaction %s failed", redactID)
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d events could not be redacted", len(failed))
+	}
+	return nil
+}
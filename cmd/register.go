@@ -0,0 +1,126 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var registerSharedSecretFile string
+var registerPasswordFile string
+var registerDisplayName string
+var registerAdmin bool
+var registerUserType string
+
+// registerCmd represents the register command
+var registerCmd = &cobra.Command{
+	Use:   "register --shared-secret-file <path> <localpart>",
+	Short: "Register a user with the registration shared secret.",
+	Long: `Registers a user through the shared-secret registration admin API, which does not need an access token.
+Use it with --admin to bootstrap the first admin on a fresh homeserver. The password is read from
+--password-file or, when not given, from the first line of standard input. The access token of the new
+account is printed so it can be added to the syncli configuration.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{annotationNoAccessToken: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		err := registerUser(cmd.Context(), config, args[0], os.Stdin)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "register_user_error",
+				"error": err,
+			}).Error("Error occurred while registering user")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(registerCmd)
+
+	registerCmd.Flags().StringVar(&registerSharedSecretFile, "shared-secret-file", "", "File containing the registration_shared_secret of the homeserver")
+	registerCmd.Flags().StringVar(&registerPasswordFile, "password-file", "", "File containing the password of the new user (default: read from standard input)")
+	registerCmd.Flags().StringVar(&registerDisplayName, "displayname", "", "Display name of the new user")
+	registerCmd.Flags().BoolVar(&registerAdmin, "admin", false, "Make the new user a server admin")
+	registerCmd.Flags().StringVar(&registerUserType, "user-type", "", "User type of the new user, such as support or bot")
+	if err := registerCmd.MarkFlagRequired("shared-secret-file"); err != nil {
+		panic(err)
+	}
+}
+
+func registerUser(ctx context.Context, config internal.Config, localpart string, stdin io.Reader) error {
+	secret, err := readSecretFile(registerSharedSecretFile)
+	if err != nil {
+		return fmt.Errorf("failed to read shared secret: %w", err)
+	}
+
+	var password string
+	if registerPasswordFile != "" {
+		password, err = readSecretFile(registerPasswordFile)
+	} else {
+		password, err = bufio.NewReader(stdin).ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		password = strings.TrimRight(password, "\r\n")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read password: %w", err)
+	}
+	if password == "" {
+		return errors.New("password must not be empty")
+	}
+
+	client := synapse.NewSynapseClient(config)
+	user, err := synapse.RegisterWithSharedSecret(ctx, client, logger, secret, synapse.SharedSecretRegistration{
+		Username:    localpart,
+		Password:    password,
+		DisplayName: registerDisplayName,
+		Admin:       registerAdmin,
+		UserType:    registerUserType,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("User ID: %s\n", user.UserID)
+	fmt.Printf("Device ID: %s\n", user.DeviceID)
+	fmt.Printf("Access token: %s\n", user.AccessToken)
+	return nil
+}
+
+// readSecretFile returns the content of path without surrounding whitespace.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return secret, nil
+}
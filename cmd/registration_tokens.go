@@ -0,0 +1,219 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var tokenValid bool
+var tokenValue string
+var tokenUsesAllowed int
+var tokenExpiry string
+var tokenLength int
+var tokenUnlimitedUses bool
+var tokenNoExpiry bool
+
+// registrationTokensCmd represents the registration-tokens command
+var registrationTokensCmd = &cobra.Command{
+	Use:   "registration-tokens",
+	Short: "Manage registration tokens",
+	Long:  `Registration-tokens command allows you to list, create, update and delete the tokens required to register on the Synapse Matrix homeserver.`,
+}
+
+// registrationTokensListCmd represents the registration-tokens list command
+var registrationTokensListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registration tokens.",
+	Long:  `Lists all registration tokens. Use --valid to only show valid tokens or --valid=false to only show expired or used up tokens.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var valid *bool
+		if cmd.Flags().Changed("valid") {
+			valid = &tokenValid
+		}
+		err := listRegistrationTokens(cmd.Context(), config, valid)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "list_registration_tokens_error",
+				"error": err,
+			}).Error("Error occurred while listing registration tokens")
+			os.Exit(1)
+		}
+	},
+}
+
+// registrationTokensCreateCmd represents the registration-tokens create command
+var registrationTokensCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a registration token.",
+	Long: `Creates a registration token. Without --token the server generates one of --length characters.
+--expiry accepts a date (2026-12-31), an RFC 3339 timestamp or a duration from now such as 7d.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := createRegistrationToken(cmd.Context(), config, cmd)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "create_registration_token_error",
+				"error": err,
+			}).Error("Error occurred while creating registration token")
+			os.Exit(1)
+		}
+	},
+}
+
+// registrationTokensUpdateCmd represents the registration-tokens update command
+var registrationTokensUpdateCmd = &cobra.Command{
+	Use:   "update <token>",
+	Short: "Update the allowed uses or expiry of a registration token.",
+	Long:  `Updates a registration token. Use --unlimited-uses or --no-expiry to remove a limit.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := updateRegistrationToken(cmd.Context(), config, cmd, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "update_registration_token_error",
+				"error": err,
+			}).Error("Error occurred while updating registration token")
+			os.Exit(1)
+		}
+	},
+}
+
+// registrationTokensDeleteCmd represents the registration-tokens delete command
+var registrationTokensDeleteCmd = &cobra.Command{
+	Use:   "delete <token>",
+	Short: "Delete a registration token.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := deleteRegistrationToken(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "delete_registration_token_error",
+				"error": err,
+			}).Error("Error occurred while deleting registration token")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(registrationTokensCmd)
+	registrationTokensCmd.AddCommand(registrationTokensListCmd)
+	registrationTokensCmd.AddCommand(registrationTokensCreateCmd)
+	registrationTokensCmd.AddCommand(registrationTokensUpdateCmd)
+	registrationTokensCmd.AddCommand(registrationTokensDeleteCmd)
+
+	registrationTokensListCmd.Flags().BoolVar(&tokenValid, "valid", false, "Only list valid tokens (--valid=false lists only invalid tokens)")
+
+	registrationTokensCreateCmd.Flags().StringVar(&tokenValue, "token", "", "Token to create (default: generated by the server)")
+	registrationTokensCreateCmd.Flags().IntVar(&tokenUsesAllowed, "uses-allowed", 0, "Number of registrations allowed (default: unlimited)")
+	registrationTokensCreateCmd.Flags().StringVar(&tokenExpiry, "expiry", "", "When the token expires, as a date or a duration from now (default: never)")
+	registrationTokensCreateCmd.Flags().IntVar(&tokenLength, "length", 0, "Length of the generated token (default: 16)")
+	registrationTokensCreateCmd.MarkFlagsMutuallyExclusive("token", "length")
+
+	registrationTokensUpdateCmd.Flags().IntVar(&tokenUsesAllowed, "uses-allowed", 0, "Number of registrations allowed")
+	registrationTokensUpdateCmd.Flags().StringVar(&tokenExpiry, "expiry", "", "When the token expires, as a date or a duration from now")
+	registrationTokensUpdateCmd.Flags().BoolVar(&tokenUnlimitedUses, "unlimited-uses", false, "Remove the limit on registrations")
+	registrationTokensUpdateCmd.Flags().BoolVar(&tokenNoExpiry, "no-expiry", false, "Make the token never expire")
+	registrationTokensUpdateCmd.MarkFlagsMutuallyExclusive("uses-allowed", "unlimited-uses")
+	registrationTokensUpdateCmd.MarkFlagsMutuallyExclusive("expiry", "no-expiry")
+}
+
+func listRegistrationTokens(ctx context.Context, config internal.Config, valid *bool) error {
+	client := synapse.NewSynapseClient(config)
+	tokens, err := synapse.ListRegistrationTokens(ctx, client, logger, valid)
+	if err != nil {
+		return err
+	}
+
+	internal.Print(tokens, false)
+	return nil
+}
+
+func createRegistrationToken(ctx context.Context, config internal.Config, cmd *cobra.Command) error {
+	req := synapse.NewRegistrationToken{Token: tokenValue}
+	if cmd.Flags().Changed("uses-allowed") {
+		req.UsesAllowed = &tokenUsesAllowed
+	}
+	if cmd.Flags().Changed("length") {
+		req.Length = &tokenLength
+	}
+	if tokenExpiry != "" {
+		expiry, err := internal.ParseFutureTime(tokenExpiry, time.Now())
+		if err != nil {
+			return err
+		}
+		ms := expiry.UnixMilli()
+		req.ExpiryTime = &ms
+	}
+
+	client := synapse.NewSynapseClient(config)
+	token, err := synapse.CreateRegistrationToken(ctx, client, logger, req)
+	if err != nil {
+		return err
+	}
+
+	internal.Print([]synapse.RegistrationToken{token}, false)
+	return nil
+}
+
+func updateRegistrationToken(ctx context.Context, config internal.Config, cmd *cobra.Command, token string) error {
+	fields := make(map[string]any)
+	if cmd.Flags().Changed("uses-allowed") {
+		fields["uses_allowed"] = tokenUsesAllowed
+	}
+	if tokenUnlimitedUses {
+		fields["uses_allowed"] = nil
+	}
+	if tokenExpiry != "" {
+		expiry, err := internal.ParseFutureTime(tokenExpiry, time.Now())
+		if err != nil {
+			return err
+		}
+		fields["expiry_time"] = expiry.UnixMilli()
+	}
+	if tokenNoExpiry {
+		fields["expiry_time"] = nil
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("nothing to update: use --uses-allowed, --unlimited-uses, --expiry or --no-expiry")
+	}
+
+	client := synapse.NewSynapseClient(config)
+	updated, err := synapse.UpdateRegistrationToken(ctx, client, logger, token, fields)
+	if err != nil {
+		return err
+	}
+
+	internal.Print([]synapse.RegistrationToken{updated}, false)
+	return nil
+}
+
+func deleteRegistrationToken(ctx context.Context, config internal.Config, token string) error {
+	client := synapse.NewSynapseClient(config)
+	if err := synapse.DeleteRegistrationToken(ctx, client, logger, token); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted registration token %s\n", token)
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var reportsQuery synapse.EventReportsQuery
+
+// reportsCmd represents the reports command
+var reportsCmd = &cobra.Command{
+	Use:   "reports",
+	Short: "Triage event reports",
+	Long:  `Reports command allows you to list, inspect and delete events reported by users of the Synapse Matrix homeserver.`,
+}
+
+// reportsListCmd represents the reports list command
+var reportsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List event reports.",
+	Long:  `Lists event reports, newest first by default. Use --from with the offset printed after the table to fetch the next page.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := listReports(cmd.Context(), config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "list_reports_error",
+				"error": err,
+			}).Error("Error occurred while listing event reports")
+			os.Exit(1)
+		}
+	},
+}
+
+// reportsShowCmd represents the reports show command
+var reportsShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show an event report with the reported event content.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := showReport(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event":  "show_report_error",
+				"report": args[0],
+				"error":  err,
+			}).Error("Error occurred while showing event report")
+			os.Exit(1)
+		}
+	},
+}
+
+// reportsDeleteCmd represents the reports delete command
+var reportsDeleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete a handled event report.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := deleteReport(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event":  "delete_report_error",
+				"report": args[0],
+				"error":  err,
+			}).Error("Error occurred while deleting event report")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reportsCmd)
+	reportsCmd.AddCommand(reportsListCmd)
+	reportsCmd.AddCommand(reportsShowCmd)
+	reportsCmd.AddCommand(reportsDeleteCmd)
+
+	reportsListCmd.Flags().StringVar(&reportsQuery.RoomID, "room", "", "Only list reports about events in this room")
+	reportsListCmd.Flags().StringVar(&reportsQuery.Reporter, "reporter", "", "Only list reports made by this user")
+	reportsListCmd.Flags().StringVar(&reportsQuery.Direction, "dir", "b", "Order direction: b (newest first) or f (oldest first)")
+	reportsListCmd.Flags().IntVar(&reportsQuery.From, "from", 0, "Offset of the first report to return")
+	reportsListCmd.Flags().IntVar(&reportsQuery.Limit, "limit", 100, "Maximum number of reports to return")
+}
+
+func listReports(ctx context.Context, config internal.Config) error {
+	if reportsQuery.Direction != "f" && reportsQuery.Direction != "b" {
+		return fmt.Errorf("unsupported direction: %s", reportsQuery.Direction)
+	}
+
+	client := synapse.NewSynapseClient(config)
+	reports, next, total, err := synapse.ListEventReports(ctx, client, logger, reportsQuery)
+	if err != nil {
+		return err
+	}
+
+	internal.Print(reports, false)
+	if next != nil {
+		fmt.Printf("Showing %d of %d reports, next page: --from %d\n", len(reports), total, *next)
+	}
+	return nil
+}
+
+func showReport(ctx context.Context, config internal.Config, arg string) error {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid report ID %q", arg)
+	}
+
+	client := synapse.NewSynapseClient(config)
+	details, err := synapse.GetEventReport(ctx, client, logger, id)
+	if err != nil {
+		return err
+	}
+
+	internal.Print(details.Fields(), false)
+	return nil
+}
+
+func deleteReport(ctx context.Context, config internal.Config, arg string) error {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid report ID %q", arg)
+	}
+
+	client := synapse.NewSynapseClient(config)
+	if err := synapse.DeleteEventReport(ctx, client, logger, id); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted event report %d\n", id)
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// roomMediaCmd represents the room-media command
+var roomMediaCmd = &cobra.Command{
+	Use:   "room-media <room>",
+	Short: "Retrieve the media referenced by a room.",
+	Long:  `Lists local and remote media referenced by a room with size, content type, uploader and quarantine status.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getRoomMedia(cmd.Context(), config, args[0])
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "get_room_media_error",
+				"room":  args[0],
+				"error": err,
+			}).Error("Error occurred while getting room media")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(roomMediaCmd)
+}
+
+func getRoomMedia(ctx context.Context, config internal.Config, roomID string) error {
+	client := synapse.NewSynapseClient(config)
+	media, err := synapse.GetRoomMedia(ctx, client, logger, roomID)
+	if err != nil {
+		return err
+	}
+
+	internal.Print(media, false)
+	return nil
+}
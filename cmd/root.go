@@ -36,8 +36,23 @@ var rootCmd = &cobra.Command{
 	Use:   "syncli",
 	Short: "CLI for interacting with Synapse Matrix homeserver",
 	Long:  `SynCLI is a command-line interface for interacting with Synapse Matrix homeserver. It provides various commands to manage users, rooms, and other aspects of the Synapse server.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if cmd.Annotations[annotationNoAccessToken] == "true" {
+			return
+		}
+		if config.AccessToken == "" {
+			logger.WithFields(logrus.Fields{
+				"event": "config_validation_failed",
+			}).Error("Access Token must be provided in the config file or as environment variables")
+			os.Exit(1)
+		}
+	},
 }
 
+// annotationNoAccessToken marks commands that talk to the server without an
+// admin access token, such as shared-secret registration.
+const annotationNoAccessToken = "no_access_token"
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -95,10 +110,10 @@ func initConfig() {
 	config.AccessToken = viper.GetString("access_token")
 	config.BaseURL = viper.GetString("base_url")
 
-	if config.AccessToken == "" || config.BaseURL == "" {
+	if config.BaseURL == "" {
 		logger.WithFields(logrus.Fields{
 			"event": "config_validation_failed",
-		}).Error("Base URL and Access Token must be provided in the config file or as environment variables")
+		}).Error("Base URL must be provided in the config file or as environment variables")
 		os.Exit(1)
 	}
 
@@ -16,6 +16,10 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"fmt"
+	"os"
+
 	"github.com/amandahla/syncli/internal"
 	"github.com/amandahla/syncli/internal/synapse"
 	"github.com/sirupsen/logrus"
@@ -28,27 +32,57 @@ var spacesCmd = &cobra.Command{
 	Short: "Retrieve a list of public spaces from the Synapse Matrix homeserver.",
 	Long:  `The list contains name, members, child count and child rooms ids`,
 	Run: func(cmd *cobra.Command, args []string) {
-		err := getSpaces(config)
+		err := getSpaces(cmd.Context(), config, spacesFormat, synapse.SpacesQuery{Server: spacesServer, ThirdPartyInstanceID: spacesThirdPartyInstance})
 		if err != nil {
 			logger.WithFields(logrus.Fields{
 				"event": "get_spaces_error",
 				"error": err,
 			}).Error("Error occurred while getting spaces")
+			os.Exit(1)
 		}
 	},
 }
 
+var spacesFormat string
+var spacesServer string
+var spacesThirdPartyInstance string
+
 func init() {
 	getCmd.AddCommand(spacesCmd)
+
+	spacesCmd.Flags().StringVarP(&spacesFormat, "format", "o", "table", "Output format: table, json, dot or mermaid")
+	spacesCmd.Flags().StringVar(&spacesServer, "server", "", "Query the public directory of a remote server instead of the local one")
+	spacesCmd.Flags().StringVar(&spacesThirdPartyInstance, "third-party-instance", "", "Query the directory of a bridged network by third party instance ID")
 }
 
-func getSpaces(config internal.Config) error {
+func getSpaces(ctx context.Context, config internal.Config, format string, query synapse.SpacesQuery) error {
+	if format != "table" && format != "json" && format != "dot" && format != "mermaid" {
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+
 	client := synapse.NewSynapseClient(config)
-	spaces, err := synapse.GetSpaces(client, logger)
+	spaces, err := synapse.GetSpaces(client, logger, query)
 	if err != nil {
 		return err
 	}
 
-	internal.Print(spaces, false)
-	return nil
+	if format == "table" {
+		internal.Print(spaces, false)
+		return nil
+	}
+	if format == "json" {
+		if spaces == nil {
+			spaces = []synapse.Space{}
+		}
+		return internal.PrintJSON(spaces)
+	}
+
+	graph, err := synapse.BuildSpaceGraph(ctx, client, logger, spaces)
+	if err != nil {
+		return err
+	}
+	if format == "dot" {
+		return graph.WriteDOT(os.Stdout)
+	}
+	return graph.WriteMermaid(os.Stdout)
 }
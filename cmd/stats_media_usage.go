@@ -0,0 +1,109 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var mediaUsageOrderBy string
+var mediaUsageDir string
+var mediaUsageFrom string
+var mediaUsageUntil string
+var mediaUsageSearch string
+var mediaUsageTop int
+
+// statsMediaUsageCmd represents the stats media-usage command
+var statsMediaUsageCmd = &cobra.Command{
+	Use:   "media-usage",
+	Short: "Report media usage per local user.",
+	Long: `Lists the number and total size of media uploaded by each local user, largest first by default.
+--from and --until accept a date (2026-01-31), an RFC 3339 timestamp or an age such as 30d.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getMediaUsage(cmd.Context(), config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "stats_media_usage_error",
+				"error": err,
+			}).Error("Error occurred while getting media usage statistics")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	statsCmd.AddCommand(statsMediaUsageCmd)
+
+	statsMediaUsageCmd.Flags().StringVar(&mediaUsageOrderBy, "order-by", "media_length", "Order by user_id, displayname, media_length or media_count")
+	statsMediaUsageCmd.Flags().StringVar(&mediaUsageDir, "dir", "b", "Order direction: f (ascending) or b (descending)")
+	statsMediaUsageCmd.Flags().StringVar(&mediaUsageFrom, "from", "", "Only count media uploaded after this time")
+	statsMediaUsageCmd.Flags().StringVar(&mediaUsageUntil, "until", "", "Only count media uploaded before this time")
+	statsMediaUsageCmd.Flags().StringVar(&mediaUsageSearch, "search", "", "Only include users whose ID or display name contains this term")
+	statsMediaUsageCmd.Flags().IntVar(&mediaUsageTop, "top", 0, "Only show the first N users (default: all)")
+}
+
+func getMediaUsage(ctx context.Context, config internal.Config) error {
+	switch mediaUsageOrderBy {
+	case "user_id", "displayname", "media_length", "media_count":
+	default:
+		return fmt.Errorf("unsupported order: %s", mediaUsageOrderBy)
+	}
+	if mediaUsageDir != "f" && mediaUsageDir != "b" {
+		return fmt.Errorf("unsupported direction: %s", mediaUsageDir)
+	}
+	if mediaUsageTop < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+
+	query := synapse.MediaStatsQuery{
+		OrderBy:    mediaUsageOrderBy,
+		Direction:  mediaUsageDir,
+		SearchTerm: mediaUsageSearch,
+		Limit:      mediaUsageTop,
+	}
+	now := time.Now()
+	if mediaUsageFrom != "" {
+		from, err := internal.ParsePastTime(mediaUsageFrom, now)
+		if err != nil {
+			return err
+		}
+		query.FromTS = from.UnixMilli()
+	}
+	if mediaUsageUntil != "" {
+		until, err := internal.ParsePastTime(mediaUsageUntil, now)
+		if err != nil {
+			return err
+		}
+		query.UntilTS = until.UnixMilli()
+	}
+
+	client := synapse.NewSynapseClient(config)
+	users, err := synapse.GetUserMediaStatistics(ctx, client, logger, query)
+	if err != nil {
+		return err
+	}
+
+	internal.Print(users, false)
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var roomsDBTop int
+
+// statsRoomsDBCmd represents the stats rooms-db command
+var statsRoomsDBCmd = &cobra.Command{
+	Use:   "rooms-db",
+	Short: "Report the rooms taking the most database space.",
+	Long:  `Lists rooms by estimated database size, largest first, with their names, aliases and member counts.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getRoomsDBStats(cmd.Context(), config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "stats_rooms_db_error",
+				"error": err,
+			}).Error("Error occurred while getting room database statistics")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	statsCmd.AddCommand(statsRoomsDBCmd)
+
+	statsRoomsDBCmd.Flags().IntVar(&roomsDBTop, "top", 10, "Only show the N largest rooms (0 shows all)")
+}
+
+func getRoomsDBStats(ctx context.Context, config internal.Config) error {
+	if roomsDBTop < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+
+	client := synapse.NewSynapseClient(config)
+	rooms, err := synapse.GetRoomDatabaseStatistics(ctx, client, logger, roomsDBTop)
+	if err != nil {
+		return err
+	}
+
+	internal.Print(rooms, false)
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check the health of the Synapse Matrix homeserver and the access token.",
+	Long: `Checks the health endpoint, the Synapse and client-server API versions and that the configured
+access token belongs to a server admin. Exits with code 1 when any check fails.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(cmd.Context(), time.Duration(viper.GetInt("timeout"))*time.Second)
+		defer cancel()
+		err := checkStatus(ctx, config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "status_check_failed",
+				"error": err,
+			}).Error("Homeserver status check failed")
+			cancel()
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
+
+func checkStatus(ctx context.Context, config internal.Config) error {
+	client := synapse.NewSynapseClient(config)
+	checks := synapse.CheckStatus(ctx, client, logger)
+	internal.Print(checks, false)
+
+	failed := 0
+	for _, c := range checks {
+		if !c.OK {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// suspendCmd represents the suspend command
+var suspendCmd = &cobra.Command{
+	Use:   "suspend <mxid>",
+	Short: "Suspend a local user.",
+	Long: `Suspends a local user. Suspended users can still log in and read, but cannot send messages, join rooms
+or change their profile until they are unsuspended.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := setUserSuspended(cmd.Context(), config, args[0], true)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "suspend_user_error",
+				"user":  args[0],
+				"error": err,
+			}).Error("Error occurred while suspending user")
+			os.Exit(1)
+		}
+	},
+}
+
+// unsuspendCmd represents the unsuspend command
+var unsuspendCmd = &cobra.Command{
+	Use:   "unsuspend <mxid>",
+	Short: "Lift the suspension of a local user.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := setUserSuspended(cmd.Context(), config, args[0], false)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "unsuspend_user_error",
+				"user":  args[0],
+				"error": err,
+			}).Error("Error occurred while unsuspending user")
+			os.Exit(1)
+		}
+	},
+}
+
+// restrictedUsersCmd represents the get restricted-users command
+var restrictedUsersCmd = &cobra.Command{
+	Use:   "restricted-users",
+	Short: "List the local users that are currently suspended or locked.",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getRestrictedUsers(cmd.Context(), config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "get_restricted_users_error",
+				"error": err,
+			}).Error("Error occurred while listing suspended and locked users")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(suspendCmd)
+	rootCmd.AddCommand(unsuspendCmd)
+	getCmd.AddCommand(restrictedUsersCmd)
+}
+
+func setUserSuspended(ctx context.Context, config internal.Config, userID string, suspend bool) error {
+	client := synapse.NewSynapseClient(config)
+	if err := synapse.SetUserSuspended(ctx, client, logger, userID, suspend); err != nil {
+		return err
+	}
+	if suspend {
+		fmt.Printf("Suspended %s\n", userID)
+	} else {
+		fmt.Printf("Unsuspended %s\n", userID)
+	}
+	return nil
+}
+
+func getRestrictedUsers(ctx context.Context, config internal.Config) error {
+	client := synapse.NewSynapseClient(config)
+	users, err := synapse.ListRestrictedUsers(ctx, client, logger)
+	if err != nil {
+		return err
+	}
+	internal.Print(users, false)
+	return nil
+}
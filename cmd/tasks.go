@@ -0,0 +1,100 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var tasksAction string
+var tasksResourceID string
+var tasksStatus string
+var tasksFrom string
+var tasksUntil string
+
+// tasksCmd represents the get tasks command
+var tasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "List the tasks queued or run by the server task scheduler.",
+	Long: `Lists scheduled tasks such as room purges, deletions and user redactions, optionally filtered by action,
+resource ID, status and time window. --from and --until accept a date (2026-01-31), an RFC 3339 timestamp
+or an age such as 30d.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := getTasks(cmd.Context(), config)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "get_tasks_error",
+				"error": err,
+			}).Error("Error occurred while listing scheduled tasks")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(tasksCmd)
+
+	tasksCmd.Flags().StringVar(&tasksAction, "action", "", "Only list tasks with this action name, e.g. shutdown_and_purge_room")
+	tasksCmd.Flags().StringVar(&tasksResourceID, "resource-id", "", "Only list tasks acting on this room or user ID")
+	tasksCmd.Flags().StringVar(&tasksStatus, "status", "", "Only list tasks with this status: scheduled, active, complete or failed")
+	tasksCmd.Flags().StringVar(&tasksFrom, "from", "", "Only list tasks updated after this time")
+	tasksCmd.Flags().StringVar(&tasksUntil, "until", "", "Only list tasks updated before this time")
+}
+
+func getTasks(ctx context.Context, config internal.Config) error {
+	switch tasksStatus {
+	case "", "scheduled", "active", "complete", "failed":
+	default:
+		return fmt.Errorf("unsupported status: %s", tasksStatus)
+	}
+
+	query := synapse.ScheduledTasksQuery{
+		ActionName: tasksAction,
+		ResourceID: tasksResourceID,
+		Status:     tasksStatus,
+	}
+	now := time.Now()
+	if tasksFrom != "" {
+		from, err := internal.ParsePastTime(tasksFrom, now)
+		if err != nil {
+			return err
+		}
+		query.FromTS = from.UnixMilli()
+	}
+	if tasksUntil != "" {
+		until, err := internal.ParsePastTime(tasksUntil, now)
+		if err != nil {
+			return err
+		}
+		query.UntilTS = until.UnixMilli()
+	}
+
+	client := synapse.NewSynapseClient(config)
+	tasks, err := synapse.ListScheduledTasks(ctx, client, logger, query)
+	if err != nil {
+		return err
+	}
+	internal.Print(tasks, false)
+	return nil
+}
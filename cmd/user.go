@@ -0,0 +1,80 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/amandahla/syncli/internal/synapse"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var userLocked bool
+
+// userCmd represents the user command
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage user accounts",
+	Long:  `User command allows you to change accounts of the Synapse Matrix homeserver.`,
+}
+
+// userUpdateCmd represents the user update command
+var userUpdateCmd = &cobra.Command{
+	Use:   "update <mxid>",
+	Short: "Update a user account.",
+	Long: `Updates the attributes given as flags and leaves the others untouched. A locked user cannot log in
+or use their existing sessions until unlocked with --locked=false.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var update synapse.UserUpdate
+		if cmd.Flags().Changed("locked") {
+			update.Locked = &userLocked
+		}
+		err := updateUser(cmd.Context(), config, args[0], update)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "update_user_error",
+				"user":  args[0],
+				"error": err,
+			}).Error("Error occurred while updating user")
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+	userCmd.AddCommand(userUpdateCmd)
+
+	userUpdateCmd.Flags().BoolVar(&userLocked, "locked", false, "Lock (--locked) or unlock (--locked=false) the account")
+}
+
+func updateUser(ctx context.Context, config internal.Config, userID string, update synapse.UserUpdate) error {
+	if update == (synapse.UserUpdate{}) {
+		return errors.New("nothing to update: set at least one flag such as --locked")
+	}
+	client := synapse.NewSynapseClient(config)
+	if err := synapse.UpdateUser(ctx, client, logger, userID, update); err != nil {
+		return err
+	}
+	fmt.Printf("Updated %s\n", userID)
+	return nil
+}
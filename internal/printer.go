@@ -16,6 +16,7 @@ limitations under the License.
 package internal
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -54,3 +55,10 @@ func dataToRow[T any](d []T) table.Row {
 	}
 	return row
 }
+
+// PrintJSON writes v to stdout as indented JSON.
+func PrintJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
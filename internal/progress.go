@@ -0,0 +1,139 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ProgressRecord remembers which items of a long running operation are done
+// so that the operation can be resumed after an interruption. Items are
+// appended to a file, one per line, as soon as they complete. The first line
+// holds a key identifying the operation, so that a record is never applied
+// to a different one. A nil *ProgressRecord records nothing.
+type ProgressRecord struct {
+	mu   sync.Mutex
+	done map[string]bool
+	file *os.File
+}
+
+// ErrProgressMismatch is returned by OpenProgressRecord when the record
+// was written for an operation with a different key.
+var ErrProgressMismatch = errors.New("progress record belongs to a different operation")
+
+const progressKeyPrefix = "# key "
+
+// OpenProgressRecord loads the items already recorded in path for the
+// operation identified by key, creating the file if needed, and opens it for
+// appending. It returns ErrProgressMismatch when path holds the progress of
+// another operation.
+func OpenProgressRecord(path string, key string) (*ProgressRecord, error) {
+	p := &ProgressRecord{done: make(map[string]bool)}
+	header := progressKeyPrefix + key
+
+	existing, err := os.Open(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to open progress record %s: %w", path, err)
+	}
+	hasHeader := false
+	if err == nil {
+		scanner := bufio.NewScanner(existing)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			if !hasHeader {
+				if line != header {
+					_ = existing.Close()
+					return nil, fmt.Errorf("%w: %s", ErrProgressMismatch, path)
+				}
+				hasHeader = true
+				continue
+			}
+			p.done[line] = true
+		}
+		cerr := existing.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read progress record %s: %w", path, err)
+		}
+		if cerr != nil {
+			return nil, cerr
+		}
+	}
+
+	p.file, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open progress record %s: %w", path, err)
+	}
+	if !hasHeader {
+		if _, err := fmt.Fprintln(p.file, header); err != nil {
+			_ = p.file.Close()
+			return nil, fmt.Errorf("failed to write progress record %s: %w", path, err)
+		}
+	}
+	return p, nil
+}
+
+// Done reports whether item was recorded by a previous or the current run.
+func (p *ProgressRecord) Done(item string) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.done[item]
+}
+
+// Len returns the number of recorded items.
+func (p *ProgressRecord) Len() int {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.done)
+}
+
+// Record marks item as done and persists it immediately.
+func (p *ProgressRecord) Record(item string) error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.done[item] {
+		return nil
+	}
+	if _, err := fmt.Fprintln(p.file, item); err != nil {
+		return fmt.Errorf("failed to write progress record: %w", err)
+	}
+	p.done[item] = true
+	return nil
+}
+
+// Close closes the underlying file.
+func (p *ProgressRecord) Close() error {
+	if p == nil {
+		return nil
+	}
+	return p.file.Close()
+}
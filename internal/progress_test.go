@@ -0,0 +1,61 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress")
+
+	p, err := OpenProgressRecord(path, "abc")
+	assert.NoError(t, err)
+	assert.False(t, p.Done("@alice:matrix.org"))
+	assert.NoError(t, p.Record("@alice:matrix.org"))
+	assert.NoError(t, p.Record("@alice:matrix.org"))
+	assert.NoError(t, p.Record("@bob:matrix.org"))
+	assert.NoError(t, p.Close())
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "# key abc\n@alice:matrix.org\n@bob:matrix.org\n", string(data))
+
+	resumed, err := OpenProgressRecord(path, "abc")
+	assert.NoError(t, err)
+	assert.True(t, resumed.Done("@alice:matrix.org"))
+	assert.True(t, resumed.Done("@bob:matrix.org"))
+	assert.Equal(t, 2, resumed.Len())
+	assert.NoError(t, resumed.Close())
+
+	// The record of one operation is never applied to another
+	_, err = OpenProgressRecord(path, "other")
+	assert.ErrorIs(t, err, ErrProgressMismatch)
+
+	legacy := filepath.Join(t.TempDir(), "legacy")
+	assert.NoError(t, os.WriteFile(legacy, []byte("@alice:matrix.org\n"), 0o600))
+	_, err = OpenProgressRecord(legacy, "abc")
+	assert.ErrorIs(t, err, ErrProgressMismatch)
+
+	var none *ProgressRecord
+	assert.False(t, none.Done("@alice:matrix.org"))
+	assert.NoError(t, none.Record("@alice:matrix.org"))
+	assert.NoError(t, none.Close())
+}
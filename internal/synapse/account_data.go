@@ -0,0 +1,181 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Account data event types decoded into readable tables.
+const (
+	AccountDataDirect      = "m.direct"
+	AccountDataIgnoredList = "m.ignored_user_list"
+)
+
+// AccountData holds the global and per-room account data of a user, keyed by event type.
+type AccountData struct {
+	Global map[string]json.RawMessage            `json:"global"`
+	Rooms  map[string]map[string]json.RawMessage `json:"rooms"`
+}
+
+// AccountDataEntry is a single account data event. Room is empty for global account data.
+type AccountDataEntry struct {
+	Room    string
+	Type    string
+	Content json.RawMessage
+}
+
+func (a AccountDataEntry) Header() []string {
+	return []string{"Room", "Type", "Content"}
+}
+
+func (a AccountDataEntry) Row() []interface{} {
+	room := a.Room
+	if room == "" {
+		room = "(global)"
+	}
+	var content bytes.Buffer
+	if err := json.Compact(&content, a.Content); err != nil {
+		return []interface{}{room, a.Type, string(a.Content)}
+	}
+	return []interface{}{room, a.Type, content.String()}
+}
+
+type accountDataResponse struct {
+	AccountData AccountData `json:"account_data"`
+}
+
+// GetAccountData returns the account data of a user.
+func GetAccountData(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, userID string) (AccountData, error) {
+	path := "/_synapse/admin/v1/users/" + userID + "/accountdata"
+	logger.WithFields(logrus.Fields{
+		"event": "get_account_data",
+		"path":  path,
+	}).Debug("Fetching account data")
+	var resp accountDataResponse
+	err := callJSON(ctx, client, path, &resp)
+	return resp.AccountData, err
+}
+
+// Entries returns the account data events, global ones first and then by
+// room and type. A non-empty room keeps only the account data of that room,
+// and a non-empty eventType keeps only events of that type.
+func (a AccountData) Entries(room string, eventType string) []AccountDataEntry {
+	entries := make([]AccountDataEntry, 0)
+	add := func(roomID string, events map[string]json.RawMessage) {
+		for t, content := range events {
+			if eventType == "" || t == eventType {
+				entries = append(entries, AccountDataEntry{Room: roomID, Type: t, Content: content})
+			}
+		}
+	}
+	if room == "" {
+		add("", a.Global)
+		for roomID, events := range a.Rooms {
+			add(roomID, events)
+		}
+	} else {
+		add(room, a.Rooms[room])
+	}
+	slices.SortFunc(entries, func(x, y AccountDataEntry) int {
+		if c := strings.Compare(x.Room, y.Room); c != 0 {
+			return c
+		}
+		return strings.Compare(x.Type, y.Type)
+	})
+	return entries
+}
+
+// DirectRoom is a room marked as a direct chat with another user in m.direct.
+type DirectRoom struct {
+	UserID string
+	RoomID string
+}
+
+func (d DirectRoom) Header() []string {
+	return []string{"User ID", "Room ID"}
+}
+
+func (d DirectRoom) Row() []interface{} {
+	return []interface{}{d.UserID, d.RoomID}
+}
+
+// ParseDirectRooms decodes the content of a m.direct event, sorted by user and
+// room. Empty content, as for a user without the event, yields no rooms.
+func ParseDirectRooms(content json.RawMessage) ([]DirectRoom, error) {
+	rooms := make([]DirectRoom, 0)
+	if len(content) == 0 {
+		return rooms, nil
+	}
+	var direct map[string][]string
+	if err := json.Unmarshal(content, &direct); err != nil {
+		return nil, fmt.Errorf("failed to parse %s content: %w", AccountDataDirect, err)
+	}
+	for user, ids := range direct {
+		for _, id := range ids {
+			rooms = append(rooms, DirectRoom{UserID: user, RoomID: id})
+		}
+	}
+	slices.SortFunc(rooms, func(a, b DirectRoom) int {
+		if c := strings.Compare(a.UserID, b.UserID); c != 0 {
+			return c
+		}
+		return strings.Compare(a.RoomID, b.RoomID)
+	})
+	return rooms, nil
+}
+
+// IgnoredUser is a user listed in m.ignored_user_list.
+type IgnoredUser struct {
+	UserID string
+}
+
+func (i IgnoredUser) Header() []string {
+	return []string{"Ignored User ID"}
+}
+
+func (i IgnoredUser) Row() []interface{} {
+	return []interface{}{i.UserID}
+}
+
+// ParseIgnoredUsers decodes the content of a m.ignored_user_list event, sorted
+// by user. Empty content yields no users.
+func ParseIgnoredUsers(content json.RawMessage) ([]IgnoredUser, error) {
+	if len(content) == 0 {
+		return []IgnoredUser{}, nil
+	}
+	var list struct {
+		IgnoredUsers map[string]json.RawMessage `json:"ignored_users"`
+	}
+	if err := json.Unmarshal(content, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse %s content: %w", AccountDataIgnoredList, err)
+	}
+	users := make([]IgnoredUser, 0, len(list.IgnoredUsers))
+	for user := range list.IgnoredUsers {
+		users = append(users, IgnoredUser{UserID: user})
+	}
+	slices.SortFunc(users, func(a, b IgnoredUser) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+	return users, nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAccountData(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{
+		"/_synapse/admin/v1/users/@a:matrix.org/accountdata": []byte(`{"account_data": {
+			"global": {
+				"m.direct": {"@b:matrix.org": ["!dm:matrix.org"]},
+				"m.push_rules": {"global": {}}
+			},
+			"rooms": {
+				"!r:matrix.org": {"m.fully_read": {"event_id": "$e"}, "m.tag": {"tags": {}}}
+			}
+		}}`),
+	}}
+	data, err := GetAccountData(context.Background(), mock, logrus.New(), "@a:matrix.org")
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name      string
+		room      string
+		eventType string
+		want      [][]interface{}
+	}{
+		{
+			name: "everything",
+			want: [][]interface{}{
+				{"(global)", "m.direct", `{"@b:matrix.org":["!dm:matrix.org"]}`},
+				{"(global)", "m.push_rules", `{"global":{}}`},
+				{"!r:matrix.org", "m.fully_read", `{"event_id":"$e"}`},
+				{"!r:matrix.org", "m.tag", `{"tags":{}}`},
+			},
+		},
+		{
+			name: "single room",
+			room: "!r:matrix.org",
+			want: [][]interface{}{
+				{"!r:matrix.org", "m.fully_read", `{"event_id":"$e"}`},
+				{"!r:matrix.org", "m.tag", `{"tags":{}}`},
+			},
+		},
+		{
+			name:      "single type",
+			eventType: "m.tag",
+			want:      [][]interface{}{{"!r:matrix.org", "m.tag", `{"tags":{}}`}},
+		},
+		{
+			name:      "unknown room",
+			room:      "!missing:matrix.org",
+			eventType: "m.tag",
+			want:      [][]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows := make([][]interface{}, 0)
+			for _, entry := range data.Entries(tc.room, tc.eventType) {
+				rows = append(rows, entry.Row())
+			}
+			assert.Equal(t, tc.want, rows)
+		})
+	}
+}
+
+func TestParseDirectRooms(t *testing.T) {
+	rooms, err := ParseDirectRooms(json.RawMessage(`{"@c:matrix.org": ["!3:matrix.org"], "@b:matrix.org": ["!2:matrix.org", "!1:matrix.org"]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []DirectRoom{
+		{UserID: "@b:matrix.org", RoomID: "!1:matrix.org"},
+		{UserID: "@b:matrix.org", RoomID: "!2:matrix.org"},
+		{UserID: "@c:matrix.org", RoomID: "!3:matrix.org"},
+	}, rooms)
+
+	_, err = ParseDirectRooms(json.RawMessage(`{"@b:matrix.org": "!1:matrix.org"}`))
+	assert.Error(t, err)
+
+	rooms, err = ParseDirectRooms(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, rooms)
+}
+
+func TestParseIgnoredUsers(t *testing.T) {
+	users, err := ParseIgnoredUsers(json.RawMessage(`{"ignored_users": {"@z:matrix.org": {}, "@b:matrix.org": {}}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []IgnoredUser{{UserID: "@b:matrix.org"}, {UserID: "@z:matrix.org"}}, users)
+
+	users, err = ParseIgnoredUsers(json.RawMessage(`{}`))
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+
+	users, err = ParseIgnoredUsers(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+}
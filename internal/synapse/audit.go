@@ -0,0 +1,151 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// Findings reported by AuditSpaceMembers.
+const (
+	AuditNotInAnyRoom = "not_in_any_room"
+	AuditNotInSpace   = "not_in_space"
+)
+
+// MemberAuditEntry is a user whose space membership does not match the child rooms.
+type MemberAuditEntry struct {
+	UserID  string
+	Finding string
+	Rooms   []string
+}
+
+func (m MemberAuditEntry) Header() []string {
+	return []string{"User", "Finding", "Rooms"}
+}
+
+func (m MemberAuditEntry) Row() []interface{} {
+	return []interface{}{m.UserID, m.Finding, strings.Join(m.Rooms, ",")}
+}
+
+type roomMembersResponse struct {
+	Members []string `json:"members"`
+	Total   int      `json:"total"`
+}
+
+// GetRoomMembers returns the user IDs of the joined members of a room.
+func GetRoomMembers(ctx context.Context, client SynapseClientInterface, roomID string) ([]string, error) {
+	output, err := client.Call(ctx, "/_synapse/admin/v1/rooms/"+roomID+"/members", "GET", nil, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp roomMembersResponse
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse members of room %s: %w", roomID, err)
+	}
+	return resp.Members, nil
+}
+
+// AuditSpaceMembers compares the joined members of a space with the joined
+// members of its child rooms. It reports users in the space who are in none
+// of its rooms and users in child rooms who are not in the space.
+func AuditSpaceMembers(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, spaceID string) ([]MemberAuditEntry, error) {
+	state, err := GetRoomState(ctx, client, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	spaceMembers, err := GetRoomMembers(ctx, client, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	var mu sync.Mutex
+	sem := make(chan struct{}, maxConcurrentRequests)
+	// roomsByUser maps each user to the child rooms they joined
+	roomsByUser := make(map[string][]string)
+
+	logger.WithFields(logrus.Fields{
+		"event":    "fetching_child_room_members",
+		"space":    spaceID,
+		"children": len(state.Children),
+	}).Debug("Fetching members of child rooms")
+
+	for _, child := range state.Children {
+		g.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+
+			defer func() { <-sem }()
+
+			members, err := GetRoomMembers(gctx, client, child.RoomID)
+			if IsNotFound(err) {
+				logger.WithFields(logrus.Fields{
+					"event": "child_room_not_found",
+					"room":  child.RoomID,
+				}).Warn("Child room is missing, skipping it")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			for _, member := range members {
+				roomsByUser[member] = append(roomsByUser[member], child.RoomID)
+			}
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	entries := make([]MemberAuditEntry, 0)
+	inSpace := make(map[string]bool, len(spaceMembers))
+	for _, member := range spaceMembers {
+		inSpace[member] = true
+		if _, ok := roomsByUser[member]; !ok {
+			entries = append(entries, MemberAuditEntry{UserID: member, Finding: AuditNotInAnyRoom, Rooms: []string{}})
+		}
+	}
+	for user, rooms := range roomsByUser {
+		if !inSpace[user] {
+			slices.Sort(rooms)
+			entries = append(entries, MemberAuditEntry{UserID: user, Finding: AuditNotInSpace, Rooms: rooms})
+		}
+	}
+	slices.SortFunc(entries, func(a, b MemberAuditEntry) int {
+		if c := strings.Compare(a.Finding, b.Finding); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	return entries, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditSpaceMembers(t *testing.T) {
+	spaceState := []byte(`{"state": [
+		{"type": "m.space.child", "state_key": "!a:matrix.org", "content": {"via": ["matrix.org"]}},
+		{"type": "m.space.child", "state_key": "!b:matrix.org", "content": {"via": ["matrix.org"]}},
+		{"type": "m.space.child", "state_key": "!gone:matrix.org", "content": {"via": ["matrix.org"]}}
+	]}`)
+
+	cases := []struct {
+		name        string
+		responses   map[string][]byte
+		errors      map[string]error
+		wantErr     bool
+		wantEntries []MemberAuditEntry
+	}{
+		{
+			name: "users on both sides",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/rooms/!s:matrix.org/state":   spaceState,
+				"/_synapse/admin/v1/rooms/!s:matrix.org/members": []byte(`{"members": ["@alice:matrix.org", "@bob:matrix.org", "@idle:matrix.org"], "total": 3}`),
+				"/_synapse/admin/v1/rooms/!a:matrix.org/members": []byte(`{"members": ["@alice:matrix.org", "@eve:matrix.org"], "total": 2}`),
+				"/_synapse/admin/v1/rooms/!b:matrix.org/members": []byte(`{"members": ["@bob:matrix.org", "@eve:matrix.org"], "total": 2}`),
+			},
+			errors: map[string]error{
+				"/_synapse/admin/v1/rooms/!gone:matrix.org/members": &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+			},
+			wantEntries: []MemberAuditEntry{
+				{UserID: "@idle:matrix.org", Finding: AuditNotInAnyRoom, Rooms: []string{}},
+				{UserID: "@eve:matrix.org", Finding: AuditNotInSpace, Rooms: []string{"!a:matrix.org", "!b:matrix.org"}},
+			},
+		},
+		{
+			name: "consistent membership",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/rooms/!s:matrix.org/state":   spaceState,
+				"/_synapse/admin/v1/rooms/!s:matrix.org/members": []byte(`{"members": ["@alice:matrix.org"], "total": 1}`),
+				"/_synapse/admin/v1/rooms/!a:matrix.org/members": []byte(`{"members": ["@alice:matrix.org"], "total": 1}`),
+				"/_synapse/admin/v1/rooms/!b:matrix.org/members": []byte(`{"members": [], "total": 0}`),
+			},
+			errors: map[string]error{
+				"/_synapse/admin/v1/rooms/!gone:matrix.org/members": &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+			},
+			wantEntries: []MemberAuditEntry{},
+		},
+		{
+			name: "child members request fails",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/rooms/!s:matrix.org/state":   spaceState,
+				"/_synapse/admin/v1/rooms/!s:matrix.org/members": []byte(`{"members": [], "total": 0}`),
+			},
+			errors: map[string]error{
+				"/_synapse/admin/v1/rooms/!a:matrix.org/members": assert.AnError,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			entries, err := AuditSpaceMembers(context.Background(), mock, logrus.New(), "!s:matrix.org")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantEntries, entries)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// BackgroundUpdate is a background update currently running on a database.
+type BackgroundUpdate struct {
+	Database          string  `json:"-"`
+	Name              string  `json:"name"`
+	TotalItemCount    int64   `json:"total_item_count"`
+	TotalDurationMS   float64 `json:"total_duration_ms"`
+	AverageItemsPerMS float64 `json:"average_items_per_ms"`
+}
+
+func (b BackgroundUpdate) Header() []string {
+	return []string{"Database", "Update", "Items Processed", "Duration", "Items/ms"}
+}
+
+func (b BackgroundUpdate) Row() []interface{} {
+	duration := time.Duration(b.TotalDurationMS * float64(time.Millisecond)).Round(time.Second)
+	return []interface{}{b.Database, b.Name, b.TotalItemCount, duration.String(), fmt.Sprintf("%.2f", b.AverageItemsPerMS)}
+}
+
+// BackgroundUpdatesStatus tells whether background updates are enabled and
+// which ones are currently running.
+type BackgroundUpdatesStatus struct {
+	Enabled bool
+	Current []BackgroundUpdate
+}
+
+type backgroundUpdatesStatusResponse struct {
+	Enabled        bool                        `json:"enabled"`
+	CurrentUpdates map[string]BackgroundUpdate `json:"current_updates"`
+}
+
+// GetBackgroundUpdatesStatus returns the state of background updates.
+func GetBackgroundUpdatesStatus(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger) (BackgroundUpdatesStatus, error) {
+	var resp backgroundUpdatesStatusResponse
+	if err := callJSON(ctx, client, "/_synapse/admin/v1/background_updates/status", &resp); err != nil {
+		return BackgroundUpdatesStatus{}, err
+	}
+	status := BackgroundUpdatesStatus{Enabled: resp.Enabled, Current: make([]BackgroundUpdate, 0, len(resp.CurrentUpdates))}
+	for db, update := range resp.CurrentUpdates {
+		update.Database = db
+		status.Current = append(status.Current, update)
+	}
+	slices.SortFunc(status.Current, func(a, b BackgroundUpdate) int {
+		return strings.Compare(a.Database, b.Database)
+	})
+	logger.WithFields(logrus.Fields{
+		"event":   "background_updates_status",
+		"enabled": status.Enabled,
+		"running": len(status.Current),
+	}).Debug("Fetched background updates status")
+	return status, nil
+}
+
+// SetBackgroundUpdatesEnabled pauses or resumes background updates and returns the resulting state.
+func SetBackgroundUpdatesEnabled(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, enabled bool) (bool, error) {
+	logger.WithFields(logrus.Fields{
+		"event":   "set_background_updates_enabled",
+		"enabled": enabled,
+	}).Debug("Changing background updates state")
+	output, err := client.Call(ctx, "/_synapse/admin/v1/background_updates/enabled", "POST", fmt.Appendf(nil, `{"enabled": %t}`, enabled), false)
+	if err != nil {
+		return false, err
+	}
+	var resp struct {
+		Enabled bool `json:"enabled"`
+	}
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return false, fmt.Errorf("failed to parse background updates state: %w", err)
+	}
+	return resp.Enabled, nil
+}
+
+// StartBackgroundUpdateJob schedules a background update job such as
+// populate_stats_process_rooms or regenerate_directory.
+func StartBackgroundUpdateJob(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, job string) error {
+	payload, err := json.Marshal(map[string]string{"job_name": job})
+	if err != nil {
+		return err
+	}
+	logger.WithFields(logrus.Fields{
+		"event": "start_background_update_job",
+		"job":   job,
+	}).Debug("Starting background update job")
+	_, err = client.Call(ctx, "/_synapse/admin/v1/background_updates/start_job", "POST", payload, false)
+	return err
+}
+
+// WaitForBackgroundUpdates polls the background updates status every interval
+// until no update is running or ctx is done. A job that was just started may
+// not be picked up by the updater yet, so an empty status only counts as
+// finished once an update has been seen running or grace has passed.
+func WaitForBackgroundUpdates(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, interval time.Duration, grace time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(grace)
+	seen := false
+	for {
+		status, err := GetBackgroundUpdatesStatus(ctx, client, logger)
+		if err != nil {
+			return err
+		}
+		if len(status.Current) > 0 {
+			seen = true
+		}
+		if len(status.Current) == 0 {
+			if seen || !time.Now().Before(deadline) {
+				return nil
+			}
+			logger.WithFields(logrus.Fields{
+				"event": "background_update_pending",
+			}).Info("Waiting for the background updater to pick up the job")
+		}
+		for _, update := range status.Current {
+			logger.WithFields(logrus.Fields{
+				"event":    "background_update_running",
+				"database": update.Database,
+				"update":   update.Name,
+				"items":    update.TotalItemCount,
+			}).Info("Background update still running")
+		}
+		if !status.Enabled {
+			logger.WithFields(logrus.Fields{
+				"event": "background_updates_disabled",
+			}).Warn("Background updates are disabled, they will not finish until enabled")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
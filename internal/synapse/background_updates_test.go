@@ -0,0 +1,135 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// sequenceClient returns its responses in order, repeating the last one.
+type sequenceClient struct {
+	responses [][]byte
+	calls     int
+}
+
+func (s *sequenceClient) Call(ctx context.Context, path string, method string, payload []byte, retry bool) ([]byte, error) {
+	i := min(s.calls, len(s.responses)-1)
+	s.calls++
+	return s.responses[i], nil
+}
+
+func TestGetBackgroundUpdatesStatus(t *testing.T) {
+	cases := []struct {
+		name        string
+		responses   map[string][]byte
+		errors      map[string]error
+		wantErr     bool
+		wantEnabled bool
+		wantRows    [][]interface{}
+	}{
+		{
+			name: "updates running",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/background_updates/status": []byte(`{"enabled": true, "current_updates": {
+					"state": {"name": "event_search_fix", "total_item_count": 10, "total_duration_ms": 2000.0, "average_items_per_ms": 0.005},
+					"main": {"name": "populate_stats", "total_item_count": 50, "total_duration_ms": 90500.0, "average_items_per_ms": 2.2}
+				}}`),
+			},
+			wantEnabled: true,
+			wantRows: [][]interface{}{
+				{"main", "populate_stats", int64(50), "1m31s", "2.20"},
+				{"state", "event_search_fix", int64(10), "2s", "0.01"},
+			},
+		},
+		{
+			name: "idle and disabled",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/background_updates/status": []byte(`{"enabled": false, "current_updates": {}}`),
+			},
+			wantRows: [][]interface{}{},
+		},
+		{
+			name:    "request fails",
+			errors:  map[string]error{"/_synapse/admin/v1/background_updates/status": assert.AnError},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			status, err := GetBackgroundUpdatesStatus(context.Background(), mock, logrus.New())
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantEnabled, status.Enabled)
+			rows := make([][]interface{}, 0)
+			for _, u := range status.Current {
+				rows = append(rows, u.Row())
+			}
+			assert.Equal(t, tc.wantRows, rows)
+		})
+	}
+}
+
+func TestControlBackgroundUpdates(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{
+		"/_synapse/admin/v1/background_updates/enabled":   []byte(`{"enabled": false}`),
+		"/_synapse/admin/v1/background_updates/start_job": []byte(`{}`),
+	}}
+
+	enabled, err := SetBackgroundUpdatesEnabled(context.Background(), mock, logrus.New(), false)
+	assert.NoError(t, err)
+	assert.False(t, enabled)
+	assert.JSONEq(t, `{"enabled": false}`, string(mock.Payloads["/_synapse/admin/v1/background_updates/enabled"]))
+
+	assert.NoError(t, StartBackgroundUpdateJob(context.Background(), mock, logrus.New(), "regenerate_directory"))
+	assert.JSONEq(t, `{"job_name": "regenerate_directory"}`, string(mock.Payloads["/_synapse/admin/v1/background_updates/start_job"]))
+}
+
+func TestWaitForBackgroundUpdates(t *testing.T) {
+	running := []byte(`{"enabled": true, "current_updates": {"main": {"name": "populate_stats", "total_item_count": 1}}}`)
+	done := []byte(`{"enabled": true, "current_updates": {}}`)
+
+	client := &sequenceClient{responses: [][]byte{running, running, done}}
+	err := WaitForBackgroundUpdates(context.Background(), client, logrus.New(), time.Millisecond, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, client.calls)
+
+	// A started job is not reported as finished before the updater picks it up
+	client = &sequenceClient{responses: [][]byte{done, done, running, done}}
+	err = WaitForBackgroundUpdates(context.Background(), client, logrus.New(), time.Millisecond, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, client.calls)
+
+	// Without any update showing up, the wait ends after the grace period
+	client = &sequenceClient{responses: [][]byte{done}}
+	err = WaitForBackgroundUpdates(context.Background(), client, logrus.New(), time.Millisecond, 10*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Greater(t, client.calls, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = WaitForBackgroundUpdates(ctx, &sequenceClient{responses: [][]byte{running}}, logrus.New(), time.Millisecond, 0)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// ManifestFile is the name of the manifest written next to the backed up media.
+const ManifestFile = "manifest.json"
+
+// BackupEntry describes a media item saved by BackupRoomMedia.
+type BackupEntry struct {
+	File        string `json:"file"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type"`
+	UploadName  string `json:"upload_name"`
+	Uploader    string `json:"uploader"`
+}
+
+// BackupManifest maps the mxc URIs of a room to the files they were saved to.
+type BackupManifest struct {
+	RoomID    string                 `json:"room_id"`
+	CreatedAt time.Time              `json:"created_at"`
+	Media     map[string]BackupEntry `json:"media"`
+	// Missing lists media referenced by the room that no longer exists on the server.
+	Missing []string `json:"missing"`
+}
+
+// DownloadMedia streams a media item to w through the authenticated client
+// media endpoint and returns the number of bytes written.
+func DownloadMedia(ctx context.Context, client SynapseDownloaderInterface, logger *logrus.Logger, server string, mediaID string, w io.Writer) (int64, error) {
+	logger.WithFields(logrus.Fields{
+		"event":  "download_media",
+		"server": server,
+		"media":  mediaID,
+	}).Debug("Downloading media")
+	return client.Download(ctx, "/_matrix/client/v1/media/download/"+server+"/"+mediaID, w)
+}
+
+// sizeCheckWriter fails as soon as more than limit bytes are written to it,
+// so an oversized download stops early. A limit of zero disables the check.
+type sizeCheckWriter struct {
+	w     io.Writer
+	limit int64
+	n     int64
+}
+
+func (s *sizeCheckWriter) Write(p []byte) (int, error) {
+	if s.limit > 0 && s.n+int64(len(p)) > s.limit {
+		return 0, fmt.Errorf("received more than the expected %d bytes", s.limit)
+	}
+	n, err := s.w.Write(p)
+	s.n += int64(n)
+	return n, err
+}
+
+// backupMediaFile streams a media item into path, removing the file again
+// when the download fails or its size does not match expectedSize.
+func backupMediaFile(ctx context.Context, client SynapseDownloaderInterface, logger *logrus.Logger, server string, mediaID string, path string, expectedSize int64) (int64, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	size, err := DownloadMedia(ctx, client, logger, server, mediaID, &sizeCheckWriter{w: f, limit: expectedSize})
+	if cerr := f.Close(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if err == nil && expectedSize > 0 && size != expectedSize {
+		err = fmt.Errorf("expected %d bytes, downloaded %d", expectedSize, size)
+	}
+	if err != nil {
+		if rerr := os.Remove(path); rerr != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "remove_partial_media_failed",
+				"file":  path,
+				"error": rerr,
+			}).Warn("Failed to remove partially downloaded media")
+		}
+		return 0, err
+	}
+	return size, nil
+}
+
+// BackupRoomMedia downloads every media item referenced by a room into dir,
+// verifies the downloaded sizes and writes a manifest describing the backup.
+func BackupRoomMedia(ctx context.Context, client SynapseDownloaderInterface, logger *logrus.Logger, roomID string, dir string) (BackupManifest, error) {
+	manifest := BackupManifest{
+		RoomID:    roomID,
+		CreatedAt: time.Now().UTC(),
+		Media:     make(map[string]BackupEntry),
+		Missing:   []string{},
+	}
+
+	media, err := GetRoomMedia(ctx, client, logger, roomID)
+	if err != nil {
+		return manifest, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return manifest, fmt.Errorf("failed to create backup directory %s: %w", dir, err)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	var mu sync.Mutex
+	sem := make(chan struct{}, maxConcurrentRequests)
+
+	logger.WithFields(logrus.Fields{
+		"event": "backing_up_room_media",
+		"room":  roomID,
+		"count": len(media),
+		"dir":   dir,
+	}).Debug("Backing up room media")
+
+	for _, m := range media {
+		g.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+
+			defer func() { <-sem }()
+
+			server, mediaID, err := ParseMediaID(m.URI)
+			if err != nil {
+				return err
+			}
+			file := filepath.Base(server + "_" + mediaID)
+			size, err := backupMediaFile(gctx, client, logger, server, mediaID, filepath.Join(dir, file), m.MediaLength)
+			if IsNotFound(err) {
+				mu.Lock()
+				manifest.Missing = append(manifest.Missing, m.URI)
+				mu.Unlock()
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to back up %s: %w", m.URI, err)
+			}
+
+			logger.WithFields(logrus.Fields{
+				"event": "media_backed_up",
+				"media": m.URI,
+				"file":  file,
+				"size":  size,
+			}).Debug("Media backed up")
+
+			mu.Lock()
+			manifest.Media[m.URI] = BackupEntry{
+				File:        file,
+				Size:        size,
+				ContentType: m.MediaType,
+				UploadName:  m.UploadName,
+				Uploader:    m.UserID,
+			}
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return manifest, err
+	}
+
+	slices.Sort(manifest.Missing)
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), data, 0o600); err != nil {
+		return manifest, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// downloadClient serves downloads from the responses and errors of MockClient.
+type downloadClient struct {
+	*MockClient
+}
+
+func (d downloadClient) Download(ctx context.Context, path string, w io.Writer) (int64, error) {
+	if err, ok := d.Errors[path]; ok && err != nil {
+		return 0, err
+	}
+	resp, ok := d.Responses[path]
+	if !ok {
+		return 0, fmt.Errorf("no mock response for path: %s", path)
+	}
+	n, err := w.Write(resp)
+	return int64(n), err
+}
+
+func TestBackupRoomMedia(t *testing.T) {
+	roomMedia := []byte(`{"local": ["mxc://matrix.org/abc", "mxc://matrix.org/gone"], "remote": ["mxc://remote.org/xyz"]}`)
+
+	cases := []struct {
+		name        string
+		responses   map[string][]byte
+		errors      map[string]error
+		wantErr     bool
+		wantFiles   map[string]string
+		wantData    map[string]string
+		wantMissing []string
+	}{
+		{
+			name: "downloads media and records missing items",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/room/!r:matrix.org/media":      roomMedia,
+				"/_synapse/admin/v1/media/matrix.org/abc":          []byte(`{"media_info": {"media_id": "abc", "media_type": "text/plain", "media_length": 5}}`),
+				"/_synapse/admin/v1/media/matrix.org/gone":         []byte(`{"media_info": {"media_id": "gone", "media_length": 0}}`),
+				"/_synapse/admin/v1/media/remote.org/xyz":          []byte(`{"media_info": {"media_origin": "remote.org", "media_id": "xyz", "media_length": 3}}`),
+				"/_matrix/client/v1/media/download/matrix.org/abc": []byte("hello"),
+				"/_matrix/client/v1/media/download/remote.org/xyz": []byte("xyz"),
+			},
+			errors: map[string]error{
+				"/_matrix/client/v1/media/download/matrix.org/gone": &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+			},
+			wantFiles: map[string]string{
+				"mxc://matrix.org/abc": "matrix.org_abc",
+				"mxc://remote.org/xyz": "remote.org_xyz",
+			},
+			wantData: map[string]string{
+				"matrix.org_abc": "hello",
+				"remote.org_xyz": "xyz",
+			},
+			wantMissing: []string{"mxc://matrix.org/gone"},
+		},
+		{
+			name: "shorter than expected",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/room/!r:matrix.org/media":      []byte(`{"local": ["mxc://matrix.org/abc"], "remote": []}`),
+				"/_synapse/admin/v1/media/matrix.org/abc":          []byte(`{"media_info": {"media_id": "abc", "media_length": 10}}`),
+				"/_matrix/client/v1/media/download/matrix.org/abc": []byte("short"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "larger than expected",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/room/!r:matrix.org/media":      []byte(`{"local": ["mxc://matrix.org/abc"], "remote": []}`),
+				"/_synapse/admin/v1/media/matrix.org/abc":          []byte(`{"media_info": {"media_id": "abc", "media_length": 2}}`),
+				"/_matrix/client/v1/media/download/matrix.org/abc": []byte("too long"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "out")
+			mock := downloadClient{&MockClient{Responses: tc.responses, Errors: tc.errors}}
+			manifest, err := BackupRoomMedia(context.Background(), mock, logrus.New(), "!r:matrix.org", dir)
+			if tc.wantErr {
+				assert.Error(t, err)
+				_, statErr := os.Stat(filepath.Join(dir, "matrix.org_abc"))
+				assert.True(t, os.IsNotExist(statErr), "partial download must be removed")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantMissing, manifest.Missing)
+			assert.Equal(t, len(tc.wantFiles), len(manifest.Media))
+			for uri, file := range tc.wantFiles {
+				assert.Equal(t, file, manifest.Media[uri].File)
+			}
+			for file, want := range tc.wantData {
+				data, err := os.ReadFile(filepath.Join(dir, file))
+				assert.NoError(t, err)
+				assert.Equal(t, want, string(data))
+			}
+			_, err = os.Stat(filepath.Join(dir, ManifestFile))
+			assert.NoError(t, err)
+		})
+	}
+}
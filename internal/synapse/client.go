@@ -18,6 +18,7 @@ package synapse
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,11 +30,35 @@ import (
 
 const maxElapsedTime = 10 * time.Second
 
+// StatusError is returned when Synapse answers with an unexpected HTTP status.
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request to %s returned unexpected status: %v", e.URL, e.Status)
+}
+
+// IsNotFound reports whether err is a 404 response from Synapse.
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
 // SynapseClientInterface defines the behavior for mocking
 type SynapseClientInterface interface {
 	Call(ctx context.Context, path string, method string, payload []byte, retry bool) ([]byte, error)
 }
 
+// SynapseDownloaderInterface is a client that can also stream large
+// responses, such as media files, without buffering them in memory.
+type SynapseDownloaderInterface interface {
+	SynapseClientInterface
+	Download(ctx context.Context, path string, w io.Writer) (int64, error)
+}
+
 // SynapseClient holds the reusable HTTP client and configuration
 type SynapseClient struct {
 	Client *http.Client
@@ -51,7 +76,7 @@ func NewSynapseClient(config internal.Config) *SynapseClient {
 
 // Call makes an HTTP request to the specified path with the given method and configuration.
 // If retry is true, it will retry the request with exponential backoff in case of failure.
-// If payload is provided and the method is POST, it will include the payload in the request body.
+// If payload is provided and the method is POST or PUT, it will include the payload in the request body.
 // It returns the response body as a byte slice or an error if the request fails.
 // Call makes an HTTP request to the specified path with the given method and configuration.
 // Accepts context.Context for cancellation and timeout propagation.
@@ -59,14 +84,16 @@ func (s *SynapseClient) Call(ctx context.Context, path string, method string, pa
 	var output []byte
 	synapseURL := fmt.Sprintf("%s%s", s.Config.BaseURL, path)
 	var sendBody io.Reader
-	if method == http.MethodPost && payload != nil {
+	if (method == http.MethodPost || method == http.MethodPut) && payload != nil {
 		sendBody = bytes.NewReader(payload)
 	}
 	req, err := http.NewRequestWithContext(ctx, method, synapseURL, sendBody)
 	if err != nil {
 		return output, fmt.Errorf("request to %s failed: %v", synapseURL, err)
 	}
-	req.Header.Set("Authorization", "Bearer "+s.Config.AccessToken)
+	if s.Config.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Config.AccessToken)
+	}
 	if retry {
 		return callWithRetry(ctx, s.Client, req, synapseURL)
 	}
@@ -81,7 +108,7 @@ func (s *SynapseClient) Call(ctx context.Context, path string, method string, pa
 		}
 	}()
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
-		return output, fmt.Errorf("request to %s returned unexpected status: %v", synapseURL, resp.Status)
+		return output, &StatusError{URL: synapseURL, StatusCode: resp.StatusCode, Status: resp.Status}
 	}
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -109,7 +136,7 @@ func callWithRetry(ctx context.Context, client *http.Client, req *http.Request,
 			}
 		}()
 		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
-			return fmt.Errorf("request to %s returned unexpected status: %v", synapseURL, resp.Status)
+			return &StatusError{URL: synapseURL, StatusCode: resp.StatusCode, Status: resp.Status}
 		}
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
@@ -120,3 +147,70 @@ func callWithRetry(ctx context.Context, client *http.Client, req *http.Request,
 	}, b)
 	return output, err
 }
+
+// Download streams the body of a GET request to w and returns the number of
+// bytes written. The client timeout only bounds the wait for the response
+// headers, so a large transfer is limited by ctx alone. Failures before the
+// first byte is written are retried with exponential backoff when
+// retryableStatusError allows it.
+func (s *SynapseClient) Download(ctx context.Context, path string, w io.Writer) (int64, error) {
+	synapseURL := fmt.Sprintf("%s%s", s.Config.BaseURL, path)
+	client := *s.Client
+	client.Timeout = 0
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = maxElapsedTime
+
+	var written int64
+	err := backoff.Retry(func() error {
+		reqCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, synapseURL, nil)
+		if err != nil {
+			return backoff.Permanent(fmt.Errorf("request to %s failed: %v", synapseURL, err))
+		}
+		if s.Config.AccessToken != "" {
+			req.Header.Set("Authorization", "Bearer "+s.Config.AccessToken)
+		}
+
+		var timer *time.Timer
+		if s.Client.Timeout > 0 {
+			timer = time.AfterFunc(s.Client.Timeout, cancel)
+		}
+		resp, err := client.Do(req)
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != nil {
+			return fmt.Errorf("request to %s failed: %v", synapseURL, err)
+		}
+		defer func() {
+			cerr := resp.Body.Close()
+			if cerr != nil {
+				fmt.Printf("failed to close response body: %v\n", cerr)
+			}
+		}()
+		if resp.StatusCode != http.StatusOK {
+			return retryableStatusError(&StatusError{URL: synapseURL, StatusCode: resp.StatusCode, Status: resp.Status})
+		}
+
+		// Part of the body may already be in w, so the copy is never retried
+		written, err = io.Copy(w, resp.Body)
+		if err != nil {
+			return backoff.Permanent(fmt.Errorf("failed to download %s: %w", synapseURL, err))
+		}
+		return nil
+	}, backoff.WithContext(b, ctx))
+	return written, err
+}
+
+// retryableStatusError marks err as permanent unless its status is worth
+// retrying: rate limiting and the gateway errors of a restarting server.
+func retryableStatusError(err *StatusError) error {
+	switch err.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return err
+	default:
+		return backoff.Permanent(err)
+	}
+}
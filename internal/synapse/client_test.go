@@ -0,0 +1,73 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSynapseClientDownload(t *testing.T) {
+	cases := []struct {
+		name         string
+		statuses     []int
+		wantErr      bool
+		wantNotFound bool
+		wantRequests int32
+		wantBody     string
+	}{
+		{name: "streams the body", statuses: []int{http.StatusOK}, wantRequests: 1, wantBody: "media"},
+		{name: "not found is not retried", statuses: []int{http.StatusNotFound}, wantErr: true, wantNotFound: true, wantRequests: 1},
+		{name: "server error is not retried", statuses: []int{http.StatusInternalServerError}, wantErr: true, wantRequests: 1},
+		{name: "unavailable is retried", statuses: []int{http.StatusServiceUnavailable, http.StatusOK}, wantRequests: 2, wantBody: "media"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+				i := int(requests.Add(1)) - 1
+				status := tc.statuses[min(i, len(tc.statuses)-1)]
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					_, _ = w.Write([]byte("media"))
+				}
+			}))
+			defer server.Close()
+
+			client := NewSynapseClient(internal.Config{BaseURL: server.URL, AccessToken: "token"})
+			var body bytes.Buffer
+			n, err := client.Download(context.Background(), "/_matrix/client/v1/media/download/matrix.org/abc", &body)
+			assert.Equal(t, tc.wantRequests, requests.Load())
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Equal(t, tc.wantNotFound, IsNotFound(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tc.wantBody)), n)
+			assert.Equal(t, tc.wantBody, body.String())
+		})
+	}
+}
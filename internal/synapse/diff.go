@@ -0,0 +1,102 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Changes reported by DiffSpaces.
+const (
+	SpaceAdded        = "added"
+	SpaceRemoved      = "removed"
+	SpaceRenamed      = "renamed"
+	SpaceMembers      = "members"
+	SpaceChildAdded   = "child_added"
+	SpaceChildRemoved = "child_removed"
+)
+
+// SpaceChange is a single difference between two space snapshots.
+type SpaceChange struct {
+	Change  string
+	SpaceID string
+	Name    string
+	Detail  string
+}
+
+func (c SpaceChange) Header() []string {
+	return []string{"Change", "Space ID", "Name", "Detail"}
+}
+
+func (c SpaceChange) Row() []interface{} {
+	return []interface{}{c.Change, c.SpaceID, c.Name, c.Detail}
+}
+
+// LoadSpaces reads a snapshot written by "get spaces -o json".
+func LoadSpaces(r io.Reader) ([]Space, error) {
+	var spaces []Space
+	if err := json.NewDecoder(r).Decode(&spaces); err != nil {
+		return nil, fmt.Errorf("failed to decode spaces snapshot: %w", err)
+	}
+	return spaces, nil
+}
+
+// DiffSpaces reports spaces added and removed between two snapshots, along
+// with name, membership and child room changes of the spaces present in both.
+func DiffSpaces(before, after []Space) []SpaceChange {
+	changes := make([]SpaceChange, 0)
+	old := make(map[string]Space, len(before))
+	for _, space := range before {
+		old[space.ID] = space
+	}
+	seen := make(map[string]bool, len(after))
+
+	for _, space := range after {
+		seen[space.ID] = true
+		prev, ok := old[space.ID]
+		if !ok {
+			changes = append(changes, SpaceChange{Change: SpaceAdded, SpaceID: space.ID, Name: space.Name, Detail: fmt.Sprintf("%d members, %d children", space.Members, space.ChildCount)})
+			continue
+		}
+		if prev.Name != space.Name {
+			changes = append(changes, SpaceChange{Change: SpaceRenamed, SpaceID: space.ID, Name: space.Name, Detail: fmt.Sprintf("%q -> %q", prev.Name, space.Name)})
+		}
+		if prev.Members != space.Members {
+			changes = append(changes, SpaceChange{Change: SpaceMembers, SpaceID: space.ID, Name: space.Name, Detail: fmt.Sprintf("%+d (%d -> %d)", space.Members-prev.Members, prev.Members, space.Members)})
+		}
+		for _, room := range space.ChildRooms {
+			if !slices.Contains(prev.ChildRooms, room) {
+				changes = append(changes, SpaceChange{Change: SpaceChildAdded, SpaceID: space.ID, Name: space.Name, Detail: room})
+			}
+		}
+		for _, room := range prev.ChildRooms {
+			if !slices.Contains(space.ChildRooms, room) {
+				changes = append(changes, SpaceChange{Change: SpaceChildRemoved, SpaceID: space.ID, Name: space.Name, Detail: room})
+			}
+		}
+	}
+
+	for _, space := range before {
+		if !seen[space.ID] {
+			changes = append(changes, SpaceChange{Change: SpaceRemoved, SpaceID: space.ID, Name: space.Name, Detail: fmt.Sprintf("%d members, %d children", space.Members, space.ChildCount)})
+		}
+	}
+
+	return changes
+}
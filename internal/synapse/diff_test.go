@@ -0,0 +1,67 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSpaces(t *testing.T) {
+	cases := []struct {
+		name   string
+		before string
+		after  string
+		want   []SpaceChange
+	}{
+		{
+			name:   "no changes",
+			before: `[{"id": "!a:matrix.org", "name": "A", "members": 3, "child_rooms": ["!r1:matrix.org"]}]`,
+			after:  `[{"id": "!a:matrix.org", "name": "A", "members": 3, "child_rooms": ["!r1:matrix.org"]}]`,
+			want:   []SpaceChange{},
+		},
+		{
+			name:   "added, removed and changed spaces",
+			before: `[{"id": "!a:matrix.org", "name": "A", "members": 3, "child_rooms": ["!r1:matrix.org", "!r2:matrix.org"]}, {"id": "!old:matrix.org", "name": "Old", "members": 1, "child_count": 0}]`,
+			after:  `[{"id": "!a:matrix.org", "name": "A2", "members": 5, "child_rooms": ["!r2:matrix.org", "!r3:matrix.org"]}, {"id": "!new:matrix.org", "name": "New", "members": 2, "child_count": 1}]`,
+			want: []SpaceChange{
+				{Change: SpaceRenamed, SpaceID: "!a:matrix.org", Name: "A2", Detail: `"A" -> "A2"`},
+				{Change: SpaceMembers, SpaceID: "!a:matrix.org", Name: "A2", Detail: "+2 (3 -> 5)"},
+				{Change: SpaceChildAdded, SpaceID: "!a:matrix.org", Name: "A2", Detail: "!r3:matrix.org"},
+				{Change: SpaceChildRemoved, SpaceID: "!a:matrix.org", Name: "A2", Detail: "!r1:matrix.org"},
+				{Change: SpaceAdded, SpaceID: "!new:matrix.org", Name: "New", Detail: "2 members, 1 children"},
+				{Change: SpaceRemoved, SpaceID: "!old:matrix.org", Name: "Old", Detail: "1 members, 0 children"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			before, err := LoadSpaces(strings.NewReader(tc.before))
+			assert.NoError(t, err)
+			after, err := LoadSpaces(strings.NewReader(tc.after))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, DiffSpaces(before, after))
+		})
+	}
+}
+
+func TestLoadSpacesInvalid(t *testing.T) {
+	_, err := LoadSpaces(strings.NewReader(`{"id": `))
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ExperimentalFeature is the state of an experimental feature for a user.
+type ExperimentalFeature struct {
+	Name    string
+	Enabled bool
+}
+
+func (f ExperimentalFeature) Header() []string {
+	return []string{"Feature", "Enabled"}
+}
+
+func (f ExperimentalFeature) Row() []interface{} {
+	return []interface{}{f.Name, f.Enabled}
+}
+
+type experimentalFeatures struct {
+	Features map[string]bool `json:"features"`
+}
+
+// ParseFeatureToggles parses arguments of the form feature=true or feature=false.
+func ParseFeatureToggles(args []string) (map[string]bool, error) {
+	features := make(map[string]bool, len(args))
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid feature %q: expected feature=true or feature=false", arg)
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for feature %s: %q", name, value)
+		}
+		features[name] = enabled
+	}
+	return features, nil
+}
+
+// GetExperimentalFeatures returns the experimental features of a user, sorted by name.
+func GetExperimentalFeatures(ctx context.Context, client SynapseClientInterface, userID string) ([]ExperimentalFeature, error) {
+	var resp experimentalFeatures
+	if err := callJSON(ctx, client, "/_synapse/admin/v1/experimental_features/"+userID, &resp); err != nil {
+		return nil, err
+	}
+	features := make([]ExperimentalFeature, 0, len(resp.Features))
+	for name, enabled := range resp.Features {
+		features = append(features, ExperimentalFeature{Name: name, Enabled: enabled})
+	}
+	slices.SortFunc(features, func(a, b ExperimentalFeature) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return features, nil
+}
+
+// SetExperimentalFeatures enables or disables the given experimental features
+// for each user in turn. Features that are not listed keep their state. It
+// stops at the first failure and returns the number of users updated so far.
+func SetExperimentalFeatures(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, users []string, features map[string]bool) (int, error) {
+	payload, err := json.Marshal(experimentalFeatures{Features: features})
+	if err != nil {
+		return 0, err
+	}
+	for i, user := range users {
+		logger.WithFields(logrus.Fields{
+			"event":    "set_experimental_features",
+			"user":     user,
+			"features": features,
+		}).Debug("Setting experimental features")
+		if _, err := client.Call(ctx, "/_synapse/admin/v1/experimental_features/"+user, "PUT", payload, false); err != nil {
+			return i, fmt.Errorf("failed to set experimental features for %s: %w", user, err)
+		}
+	}
+	return len(users), nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFeatureToggles(t *testing.T) {
+	cases := []struct {
+		name    string
+		args    []string
+		want    map[string]bool
+		wantErr bool
+	}{
+		{name: "toggles", args: []string{"msc3881=true", "msc3575=false"}, want: map[string]bool{"msc3881": true, "msc3575": false}},
+		{name: "missing value", args: []string{"msc3881"}, wantErr: true},
+		{name: "invalid value", args: []string{"msc3881=maybe"}, wantErr: true},
+		{name: "missing name", args: []string{"=true"}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseFeatureToggles(tc.args)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGetExperimentalFeatures(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{
+		"/_synapse/admin/v1/experimental_features/@a:matrix.org": []byte(`{"features": {"msc3881": true, "msc3026": false}}`),
+	}}
+	features, err := GetExperimentalFeatures(context.Background(), mock, "@a:matrix.org")
+	assert.NoError(t, err)
+	assert.Equal(t, []ExperimentalFeature{{Name: "msc3026", Enabled: false}, {Name: "msc3881", Enabled: true}}, features)
+}
+
+func TestSetExperimentalFeatures(t *testing.T) {
+	cases := []struct {
+		name        string
+		users       []string
+		errors      map[string]error
+		wantApplied int
+		wantErr     bool
+	}{
+		{
+			name:        "all users",
+			users:       []string{"@a:matrix.org", "@b:matrix.org"},
+			wantApplied: 2,
+		},
+		{
+			name:  "stops at first failure",
+			users: []string{"@a:matrix.org", "@remote:other.org", "@b:matrix.org"},
+			errors: map[string]error{
+				"/_synapse/admin/v1/experimental_features/@remote:other.org": &StatusError{URL: "test", StatusCode: http.StatusBadRequest, Status: "400 Bad Request"},
+			},
+			wantApplied: 1,
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{
+				Responses: map[string][]byte{
+					"/_synapse/admin/v1/experimental_features/@a:matrix.org": []byte(`{}`),
+					"/_synapse/admin/v1/experimental_features/@b:matrix.org": []byte(`{}`),
+				},
+				Errors: tc.errors,
+			}
+			applied, err := SetExperimentalFeatures(context.Background(), mock, logrus.New(), tc.users, map[string]bool{"msc3881": true})
+			assert.Equal(t, tc.wantApplied, applied)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, `{"features": {"msc3881": true}}`, string(mock.Payloads["/_synapse/admin/v1/experimental_features/@a:matrix.org"]))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Destination is the federation connection state towards a remote server.
+type Destination struct {
+	Destination string `json:"destination"`
+	// RetryLastTS, RetryInterval and FailureTS are in milliseconds. FailureTS
+	// is nil while the destination is healthy.
+	RetryLastTS                  int64  `json:"retry_last_ts"`
+	RetryInterval                int64  `json:"retry_interval"`
+	FailureTS                    *int64 `json:"failure_ts"`
+	LastSuccessfulStreamOrdering *int64 `json:"last_successful_stream_ordering"`
+}
+
+func (d Destination) Header() []string {
+	return []string{"Destination", "Failing Since", "Last Retry", "Retry Interval"}
+}
+
+func (d Destination) Row() []interface{} {
+	failingSince, lastRetry := "", ""
+	if d.FailureTS != nil {
+		failingSince = time.UnixMilli(*d.FailureTS).UTC().Format(time.RFC3339)
+	}
+	if d.RetryLastTS > 0 {
+		lastRetry = time.UnixMilli(d.RetryLastTS).UTC().Format(time.RFC3339)
+	}
+	return []interface{}{d.Destination, failingSince, lastRetry, (time.Duration(d.RetryInterval) * time.Millisecond).String()}
+}
+
+// Failing reports whether the last federation attempt to the destination failed.
+func (d Destination) Failing() bool {
+	return d.FailureTS != nil
+}
+
+// DestinationRoom is a room shared with a remote server.
+type DestinationRoom struct {
+	RoomID         string `json:"room_id"`
+	StreamOrdering int64  `json:"stream_ordering"`
+}
+
+func (r DestinationRoom) Header() []string {
+	return []string{"Room ID", "Stream Ordering"}
+}
+
+func (r DestinationRoom) Row() []interface{} {
+	return []interface{}{r.RoomID, r.StreamOrdering}
+}
+
+type destinationsResponse struct {
+	Destinations []Destination `json:"destinations"`
+	NextToken    string        `json:"next_token"`
+	Total        int           `json:"total"`
+}
+
+type destinationRoomsResponse struct {
+	Rooms     []DestinationRoom `json:"rooms"`
+	NextToken string            `json:"next_token"`
+	Total     int               `json:"total"`
+}
+
+const federationPageSize = 100
+
+// ListDestinations returns every federation destination known to the server,
+// optionally only those whose name contains search.
+func ListDestinations(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, search string) ([]Destination, error) {
+	destinations := make([]Destination, 0)
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(federationPageSize))
+	if search != "" {
+		params.Set("destination", search)
+	}
+
+	for {
+		logger.WithFields(logrus.Fields{
+			"event":  "list_federation_destinations",
+			"params": params.Encode(),
+		}).Debug("Listing federation destinations")
+		var resp destinationsResponse
+		if err := callJSON(ctx, client, "/_synapse/admin/v1/federation/destinations?"+params.Encode(), &resp); err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, resp.Destinations...)
+		if resp.NextToken == "" {
+			return destinations, nil
+		}
+		params.Set("from", resp.NextToken)
+	}
+}
+
+// GetDestination returns the federation state of a single destination.
+func GetDestination(ctx context.Context, client SynapseClientInterface, destination string) (Destination, error) {
+	var resp Destination
+	err := callJSON(ctx, client, "/_synapse/admin/v1/federation/destinations/"+url.PathEscape(destination), &resp)
+	return resp, err
+}
+
+// GetDestinationRooms returns every room shared with a destination.
+func GetDestinationRooms(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, destination string) ([]DestinationRoom, error) {
+	rooms := make([]DestinationRoom, 0)
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(federationPageSize))
+
+	for {
+		logger.WithFields(logrus.Fields{
+			"event":       "list_destination_rooms",
+			"destination": destination,
+			"params":      params.Encode(),
+		}).Debug("Listing rooms shared with destination")
+		var resp destinationRoomsResponse
+		if err := callJSON(ctx, client, "/_synapse/admin/v1/federation/destinations/"+url.PathEscape(destination)+"/rooms?"+params.Encode(), &resp); err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, resp.Rooms...)
+		if resp.NextToken == "" {
+			return rooms, nil
+		}
+		params.Set("from", resp.NextToken)
+	}
+}
+
+// ResetDestination resets the connection backoff of a destination so that
+// the server retries it immediately.
+func ResetDestination(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, destination string) error {
+	logger.WithFields(logrus.Fields{
+		"event":       "reset_federation_destination",
+		"destination": destination,
+	}).Debug("Resetting federation destination")
+	_, err := client.Call(ctx, "/_synapse/admin/v1/federation/destinations/"+url.PathEscape(destination)+"/reset_connection", "POST", []byte(`{}`), false)
+	return err
+}
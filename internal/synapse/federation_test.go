@@ -0,0 +1,94 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListDestinations(t *testing.T) {
+	cases := []struct {
+		name        string
+		search      string
+		responses   map[string][]byte
+		errors      map[string]error
+		wantErr     bool
+		wantFailing []bool
+	}{
+		{
+			name: "two pages",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/federation/destinations?limit=100":        []byte(`{"destinations": [{"destination": "a.org", "retry_last_ts": 0, "retry_interval": 0, "failure_ts": null}], "next_token": "1", "total": 2}`),
+				"/_synapse/admin/v1/federation/destinations?from=1&limit=100": []byte(`{"destinations": [{"destination": "b.org", "retry_last_ts": 1700000000000, "retry_interval": 600000, "failure_ts": 1690000000000}], "total": 2}`),
+			},
+			wantFailing: []bool{false, true},
+		},
+		{
+			name:   "search",
+			search: "matrix",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/federation/destinations?destination=matrix&limit=100": []byte(`{"destinations": [], "total": 0}`),
+			},
+			wantFailing: []bool{},
+		},
+		{
+			name:    "request fails",
+			errors:  map[string]error{"/_synapse/admin/v1/federation/destinations?limit=100": assert.AnError},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			destinations, err := ListDestinations(context.Background(), mock, logrus.New(), tc.search)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			failing := make([]bool, 0)
+			for _, d := range destinations {
+				failing = append(failing, d.Failing())
+			}
+			assert.Equal(t, tc.wantFailing, failing)
+		})
+	}
+}
+
+func TestDestinationDetailsAndReset(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{
+		"/_synapse/admin/v1/federation/destinations/b.org":                        []byte(`{"destination": "b.org", "retry_last_ts": 1700000000000, "retry_interval": 600000, "failure_ts": 1690000000000}`),
+		"/_synapse/admin/v1/federation/destinations/b.org/rooms?limit=100":        []byte(`{"rooms": [{"room_id": "!r:b.org", "stream_ordering": 8}], "next_token": "1", "total": 2}`),
+		"/_synapse/admin/v1/federation/destinations/b.org/rooms?from=1&limit=100": []byte(`{"rooms": [{"room_id": "!s:b.org", "stream_ordering": 9}], "total": 2}`),
+		"/_synapse/admin/v1/federation/destinations/b.org/reset_connection":       []byte(`{}`),
+	}}
+
+	destination, err := GetDestination(context.Background(), mock, "b.org")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"b.org", "2023-07-22T04:26:40Z", "2023-11-14T22:13:20Z", "10m0s"}, destination.Row())
+
+	rooms, err := GetDestinationRooms(context.Background(), mock, logrus.New(), "b.org")
+	assert.NoError(t, err)
+	assert.Equal(t, []DestinationRoom{{RoomID: "!r:b.org", StreamOrdering: 8}, {RoomID: "!s:b.org", StreamOrdering: 9}}, rooms)
+
+	assert.NoError(t, ResetDestination(context.Background(), mock, logrus.New(), "b.org"))
+	assert.Error(t, ResetDestination(context.Background(), mock, logrus.New(), "c.org"))
+}
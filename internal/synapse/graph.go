@@ -0,0 +1,203 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// GraphNode is a space or room in the space graph.
+type GraphNode struct {
+	ID      string
+	Name    string
+	Members int
+	IsSpace bool
+}
+
+// GraphEdge links a space to one of its children.
+type GraphEdge struct {
+	From      string
+	To        string
+	Suggested bool
+}
+
+// SpaceGraph is the space/room graph built from m.space.child events.
+type SpaceGraph struct {
+	Nodes []GraphNode
+	Edges []GraphEdge
+}
+
+// BuildSpaceGraph builds the graph for the whole hierarchy below the given
+// spaces, following m.space.child events through nested subspaces. Rooms that
+// are not part of the list are looked up through the admin room details
+// endpoint so that every node carries a name and a member count.
+func BuildSpaceGraph(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, spaces []Space) (SpaceGraph, error) {
+	var graph SpaceGraph
+	roots := make([]string, 0, len(spaces))
+	for _, space := range spaces {
+		roots = append(roots, space.ID)
+	}
+
+	states, order, missing, err := fetchHierarchy(ctx, client, logger, roots)
+	if err != nil {
+		return graph, err
+	}
+
+	index := make(map[string]int)
+	addNode := func(node GraphNode) {
+		if _, ok := index[node.ID]; !ok {
+			index[node.ID] = len(graph.Nodes)
+			graph.Nodes = append(graph.Nodes, node)
+		}
+	}
+	for _, space := range spaces {
+		addNode(GraphNode{ID: space.ID, Name: space.Name, Members: space.Members, IsSpace: true})
+	}
+	listed := len(graph.Nodes)
+	for _, id := range order {
+		addNode(GraphNode{ID: id, IsSpace: states[id].IsSpace})
+	}
+	for _, id := range order {
+		for _, child := range states[id].Children {
+			graph.Edges = append(graph.Edges, GraphEdge{From: id, To: child.RoomID, Suggested: child.Suggested})
+			addNode(GraphNode{ID: child.RoomID})
+		}
+	}
+
+	// Rooms that no longer exist on the server keep their ID as label
+	lookup := make([]int, 0, len(graph.Nodes))
+	for i := listed; i < len(graph.Nodes); i++ {
+		if !missing[graph.Nodes[i].ID] {
+			lookup = append(lookup, i)
+		}
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	var mu sync.Mutex
+	sem := make(chan struct{}, maxConcurrentRequests)
+
+	logger.WithFields(logrus.Fields{
+		"event": "fetching_graph_rooms",
+		"count": len(lookup),
+	}).Debug("Fetching details for child rooms")
+
+	for _, i := range lookup {
+		g.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			defer func() { <-sem }()
+
+			details, err := GetRoomDetails(ctx, client, graph.Nodes[i].ID)
+			if err != nil {
+				// A missing child should not prevent the rest of the graph from rendering
+				logger.WithFields(logrus.Fields{
+					"event": "fetch_graph_room_failed",
+					"room":  graph.Nodes[i].ID,
+					"error": err,
+				}).Warn("Could not fetch details for child room")
+				return nil
+			}
+
+			mu.Lock()
+			graph.Nodes[i].Name = details.Name
+			graph.Nodes[i].Members = details.JoinedMembers
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return graph, err
+	}
+
+	return graph, nil
+}
+
+// WriteDOT renders the graph in Graphviz DOT format.
+func (g SpaceGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph spaces {\n")
+	for _, n := range g.Nodes {
+		shape := "ellipse"
+		if n.IsSpace {
+			shape = "box"
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", dotQuote(n.ID), dotQuote(n.label()), shape)
+	}
+	for _, e := range g.Edges {
+		if e.Suggested {
+			fmt.Fprintf(&b, "  %s -> %s [label=\"suggested\", style=bold];\n", dotQuote(e.From), dotQuote(e.To))
+		} else {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(e.From), dotQuote(e.To))
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid renders the graph as a Mermaid flowchart.
+func (g SpaceGraph) WriteMermaid(w io.Writer) error {
+	// Mermaid node IDs cannot contain the characters used in room IDs
+	ids := make(map[string]string, len(g.Nodes))
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, n := range g.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		if n.IsSpace {
+			fmt.Fprintf(&b, "  %s[%s]\n", ids[n.ID], mermaidQuote(n.label()))
+		} else {
+			fmt.Fprintf(&b, "  %s(%s)\n", ids[n.ID], mermaidQuote(n.label()))
+		}
+	}
+	for _, e := range g.Edges {
+		if e.Suggested {
+			fmt.Fprintf(&b, "  %s -->|suggested| %s\n", ids[e.From], ids[e.To])
+		} else {
+			fmt.Fprintf(&b, "  %s --> %s\n", ids[e.From], ids[e.To])
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (n GraphNode) label() string {
+	name := n.Name
+	if name == "" {
+		name = n.ID
+	}
+	return fmt.Sprintf("%s (%d members)", name, n.Members)
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSpaceGraph(t *testing.T) {
+	cases := []struct {
+		name        string
+		responses   map[string][]byte
+		errors      map[string]error
+		wantNodes   int
+		wantEdges   int
+		wantDOT     []string
+		wantMermaid []string
+	}{
+		{
+			name: "space with suggested and regular child",
+			responses: map[string][]byte{
+				"/_matrix/client/v3/publicRooms":                   []byte(`{"chunk": [{"room_id": "!space:matrix.org", "name": "Community", "num_joined_members": 10}]}`),
+				"/_synapse/admin/v1/rooms/!space:matrix.org/state": []byte(`{"state": [{"type": "m.space.child", "state_key": "!a:matrix.org", "content": {"via": ["matrix.org"], "suggested": true}}, {"type": "m.space.child", "state_key": "!b:matrix.org", "content": {"via": ["matrix.org"]}}]}`),
+				"/_synapse/admin/v1/rooms/!a:matrix.org/state":     []byte(`{"state": []}`),
+				"/_synapse/admin/v1/rooms/!b:matrix.org/state":     []byte(`{"state": []}`),
+				"/_synapse/admin/v1/rooms/!a:matrix.org":           []byte(`{"room_id": "!a:matrix.org", "name": "General", "joined_members": 8}`),
+				"/_synapse/admin/v1/rooms/!b:matrix.org":           []byte(`{"room_id": "!b:matrix.org", "name": "Off \"topic\"", "joined_members": 3}`),
+			},
+			wantNodes: 3,
+			wantEdges: 2,
+			wantDOT: []string{
+				`"!space:matrix.org" [label="Community (10 members)", shape=box];`,
+				`"!b:matrix.org" [label="Off \"topic\" (3 members)", shape=ellipse];`,
+				`"!space:matrix.org" -> "!a:matrix.org" [label="suggested", style=bold];`,
+				`"!space:matrix.org" -> "!b:matrix.org";`,
+			},
+			wantMermaid: []string{
+				`n0["Community (10 members)"]`,
+				`n2("Off #quot;topic#quot; (3 members)")`,
+				`n0 -->|suggested| n1`,
+				`n0 --> n2`,
+			},
+		},
+		{
+			name: "nested subspaces are followed",
+			responses: map[string][]byte{
+				"/_matrix/client/v3/publicRooms":                   []byte(`{"chunk": [{"room_id": "!space:matrix.org", "name": "Community", "num_joined_members": 10}]}`),
+				"/_synapse/admin/v1/rooms/!space:matrix.org/state": []byte(`{"state": [{"type": "m.space.child", "state_key": "!sub:matrix.org", "content": {"via": ["matrix.org"]}}]}`),
+				"/_synapse/admin/v1/rooms/!sub:matrix.org/state":   []byte(`{"state": [{"type": "m.room.create", "state_key": "", "content": {"type": "m.space"}}, {"type": "m.space.child", "state_key": "!deep:matrix.org", "content": {"via": ["matrix.org"]}}, {"type": "m.space.child", "state_key": "!space:matrix.org", "content": {"via": ["matrix.org"]}}]}`),
+				"/_synapse/admin/v1/rooms/!deep:matrix.org/state":  []byte(`{"state": []}`),
+				"/_synapse/admin/v1/rooms/!sub:matrix.org":         []byte(`{"room_id": "!sub:matrix.org", "name": "Teams", "joined_members": 5}`),
+				"/_synapse/admin/v1/rooms/!deep:matrix.org":        []byte(`{"room_id": "!deep:matrix.org", "name": "Design", "joined_members": 2}`),
+			},
+			wantNodes: 3,
+			wantEdges: 3,
+			wantDOT: []string{
+				`"!sub:matrix.org" [label="Teams (5 members)", shape=box];`,
+				`"!deep:matrix.org" [label="Design (2 members)", shape=ellipse];`,
+				`"!sub:matrix.org" -> "!deep:matrix.org";`,
+				`"!sub:matrix.org" -> "!space:matrix.org";`,
+			},
+			wantMermaid: []string{
+				`n1["Teams (5 members)"]`,
+				`n1 --> n2`,
+				`n1 --> n0`,
+			},
+		},
+		{
+			name: "missing child room is kept with its ID",
+			responses: map[string][]byte{
+				"/_matrix/client/v3/publicRooms":                   []byte(`{"chunk": [{"room_id": "!space:matrix.org", "name": "Community", "num_joined_members": 10}]}`),
+				"/_synapse/admin/v1/rooms/!space:matrix.org/state": []byte(`{"state": [{"type": "m.space.child", "state_key": "!gone:matrix.org", "content": {"via": ["matrix.org"]}}]}`),
+			},
+			errors: map[string]error{
+				"/_synapse/admin/v1/rooms/!gone:matrix.org/state": &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+			},
+			wantNodes:   2,
+			wantEdges:   1,
+			wantDOT:     []string{`"!gone:matrix.org" [label="!gone:matrix.org (0 members)", shape=ellipse];`},
+			wantMermaid: []string{`n1("!gone:matrix.org (0 members)")`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			logger := logrus.New()
+			spaces, err := GetSpaces(mock, logger, SpacesQuery{})
+			assert.NoError(t, err)
+
+			graph, err := BuildSpaceGraph(context.Background(), mock, logger, spaces)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantNodes, len(graph.Nodes))
+			assert.Equal(t, tc.wantEdges, len(graph.Edges))
+
+			var dot strings.Builder
+			assert.NoError(t, graph.WriteDOT(&dot))
+			for _, want := range tc.wantDOT {
+				assert.Contains(t, dot.String(), want)
+			}
+
+			var mermaid strings.Builder
+			assert.NoError(t, graph.WriteMermaid(&mermaid))
+			for _, want := range tc.wantMermaid {
+				assert.Contains(t, mermaid.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// Checks reported by LintSpaces.
+const (
+	LintDanglingChild    = "dangling_child"
+	LintUnjoinedChild    = "unjoined_child"
+	LintCycle            = "cycle"
+	LintMissingVia       = "missing_via"
+	LintMissingParent    = "missing_parent"
+	LintStricterJoinRule = "stricter_join_rule"
+	LintEmptySpace       = "empty_space"
+)
+
+// LintIssue is a single problem found in a space hierarchy.
+type LintIssue struct {
+	Check  string
+	Space  string
+	Room   string
+	Detail string
+}
+
+func (l LintIssue) Header() []string {
+	return []string{"Check", "Space", "Room", "Detail"}
+}
+
+func (l LintIssue) Row() []interface{} {
+	return []interface{}{l.Check, l.Space, l.Room, l.Detail}
+}
+
+// LintSpaces walks the hierarchy below the given spaces and reports dangling
+// children, children on other servers that this server never joined, cycles, children without via, missing reciprocal m.space.parent
+// events, children with stricter join rules than their parent and spaces
+// without children.
+func LintSpaces(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, spaces []Space) ([]LintIssue, error) {
+	roots := make([]string, 0, len(spaces))
+	for _, space := range spaces {
+		roots = append(roots, space.ID)
+	}
+
+	states, order, missing, err := fetchHierarchy(ctx, client, logger, roots)
+	if err != nil {
+		return nil, err
+	}
+
+	// A 404 for a room of another server usually means it was never joined
+	// here, so only rooms of the local server are reported as dangling
+	serverName := ""
+	if len(missing) > 0 {
+		serverName, err = localServerName(ctx, client)
+		if err != nil {
+			logger.WithFields(logrus.Fields{
+				"event": "server_name_error",
+				"error": err,
+			}).Warn("Could not determine the local server name, reporting missing children as unjoined")
+		}
+	}
+
+	issues := make([]LintIssue, 0)
+	for _, id := range order {
+		state := states[id]
+		if !state.IsSpace && !slices.Contains(roots, id) {
+			continue
+		}
+		if len(state.Children) == 0 {
+			issues = append(issues, LintIssue{Check: LintEmptySpace, Space: id, Detail: "space has no children"})
+		}
+		for _, invalid := range state.InvalidChildren {
+			issues = append(issues, LintIssue{Check: LintMissingVia, Space: id, Room: invalid.RoomID, Detail: invalid.Reason})
+		}
+		for _, child := range state.Children {
+			if missing[child.RoomID] {
+				if serverName != "" && roomServerName(child.RoomID) == serverName {
+					issues = append(issues, LintIssue{Check: LintDanglingChild, Space: id, Room: child.RoomID, Detail: "child room was purged or never existed"})
+				} else {
+					issues = append(issues, LintIssue{Check: LintUnjoinedChild, Space: id, Room: child.RoomID, Detail: "child room is not joined by this server, its state cannot be checked"})
+				}
+				continue
+			}
+			childState := states[child.RoomID]
+			if !slices.Contains(childState.Parents, id) {
+				issues = append(issues, LintIssue{Check: LintMissingParent, Space: id, Room: child.RoomID, Detail: "child has no m.space.parent pointing back"})
+			}
+			if isStricterJoinRule(state, childState) {
+				issues = append(issues, LintIssue{
+					Check:  LintStricterJoinRule,
+					Space:  id,
+					Room:   child.RoomID,
+					Detail: fmt.Sprintf("child join rule %q is stricter than parent %q", joinRuleOrDefault(childState.JoinRule), joinRuleOrDefault(state.JoinRule)),
+				})
+			}
+		}
+	}
+
+	for _, cycle := range findCycles(states, order) {
+		issues = append(issues, LintIssue{Check: LintCycle, Space: cycle[0], Detail: strings.Join(cycle, " -> ")})
+	}
+
+	logger.WithFields(logrus.Fields{
+		"event":  "linted_spaces",
+		"rooms":  len(states),
+		"issues": len(issues),
+	}).Debug("Linted space hierarchy")
+
+	return issues, nil
+}
+
+// localServerName returns the server name of the user the client is
+// authenticated as.
+func localServerName(ctx context.Context, client SynapseClientInterface) (string, error) {
+	var whoami whoamiResponse
+	if err := callJSON(ctx, client, "/_matrix/client/v3/account/whoami", &whoami); err != nil {
+		return "", err
+	}
+	_, server, ok := strings.Cut(whoami.UserID, ":")
+	if !ok {
+		return "", fmt.Errorf("unexpected user ID %q", whoami.UserID)
+	}
+	return server, nil
+}
+
+// roomServerName returns the server part of a room ID, or an empty string for
+// room IDs without one.
+func roomServerName(roomID string) string {
+	_, server, _ := strings.Cut(roomID, ":")
+	return server
+}
+
+// fetchHierarchy fetches the state of every room reachable from roots, level by
+// level. Rooms that no longer exist on the server are reported in missing.
+func fetchHierarchy(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, roots []string) (map[string]RoomState, []string, map[string]bool, error) {
+	states := make(map[string]RoomState)
+	missing := make(map[string]bool)
+	visited := make(map[string]bool)
+	order := make([]string, 0)
+
+	level := make([]string, 0, len(roots))
+	for _, id := range roots {
+		if !visited[id] {
+			visited[id] = true
+			level = append(level, id)
+		}
+	}
+
+	for len(level) > 0 {
+		g, gctx := errgroup.WithContext(ctx)
+		var mu sync.Mutex
+		sem := make(chan struct{}, maxConcurrentRequests)
+
+		logger.WithFields(logrus.Fields{
+			"event": "fetching_hierarchy_level",
+			"count": len(level),
+		}).Debug("Fetching room states for hierarchy level")
+
+		for _, id := range level {
+			g.Go(func() error {
+				select {
+				case sem <- struct{}{}:
+				case <-gctx.Done():
+					return gctx.Err()
+				}
+
+				defer func() { <-sem }()
+
+				state, err := GetRoomState(gctx, client, id)
+				if IsNotFound(err) {
+					mu.Lock()
+					missing[id] = true
+					mu.Unlock()
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+
+				mu.Lock()
+				states[id] = state
+				mu.Unlock()
+				return nil
+			})
+		}
+
+		if err := g.Wait(); err != nil {
+			return nil, nil, nil, err
+		}
+
+		next := make([]string, 0)
+		for _, id := range level {
+			state, ok := states[id]
+			if !ok {
+				continue
+			}
+			order = append(order, id)
+			for _, child := range state.Children {
+				if !visited[child.RoomID] {
+					visited[child.RoomID] = true
+					next = append(next, child.RoomID)
+				}
+			}
+		}
+		level = next
+	}
+
+	return states, order, missing, nil
+}
+
+// findCycles returns every cycle in the space graph as a path that starts and
+// ends with the same room ID.
+func findCycles(states map[string]RoomState, order []string) [][]string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	color := make(map[string]int)
+	cycles := make([][]string, 0)
+	path := make([]string, 0)
+
+	var visit func(id string)
+	visit = func(id string) {
+		color[id] = inProgress
+		path = append(path, id)
+		for _, child := range states[id].Children {
+			if _, ok := states[child.RoomID]; !ok {
+				continue
+			}
+			switch color[child.RoomID] {
+			case unvisited:
+				visit(child.RoomID)
+			case inProgress:
+				start := slices.Index(path, child.RoomID)
+				cycle := slices.Clone(path[start:])
+				cycles = append(cycles, append(cycle, child.RoomID))
+			}
+		}
+		path = path[:len(path)-1]
+		color[id] = done
+	}
+
+	for _, id := range order {
+		if color[id] == unvisited {
+			visit(id)
+		}
+	}
+	return cycles
+}
+
+// isStricterJoinRule reports whether users allowed into parent would still be
+// unable to join child. A restricted child that allows members of parent is
+// not considered stricter.
+func isStricterJoinRule(parent, child RoomState) bool {
+	if (child.JoinRule == "restricted" || child.JoinRule == "knock_restricted") && slices.Contains(child.Allow, parent.RoomID) {
+		return false
+	}
+	return joinRuleRank(child.JoinRule) > joinRuleRank(parent.JoinRule)
+}
+
+func joinRuleRank(rule string) int {
+	switch rule {
+	case "public":
+		return 0
+	case "restricted", "knock_restricted":
+		return 1
+	case "knock":
+		return 2
+	default:
+		return 3
+	}
+}
+
+// joinRuleOrDefault applies the spec default of invite when a room has no join rules event.
+func joinRuleOrDefault(rule string) string {
+	if rule == "" {
+		return "invite"
+	}
+	return rule
+}
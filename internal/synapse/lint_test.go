@@ -0,0 +1,167 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintSpaces(t *testing.T) {
+	notFound := &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"}
+
+	cases := []struct {
+		name       string
+		spaces     []Space
+		responses  map[string][]byte
+		errors     map[string]error
+		wantErr    bool
+		wantChecks map[string]int
+	}{
+		{
+			name:   "every kind of issue",
+			spaces: []Space{{ID: "!s:matrix.org"}, {ID: "!e:matrix.org"}},
+			responses: map[string][]byte{
+				"/_matrix/client/v3/account/whoami": []byte(`{"user_id": "@admin:matrix.org"}`),
+				"/_synapse/admin/v1/rooms/!s:matrix.org/state": []byte(`{"state": [
+					{"type": "m.room.create", "state_key": "", "content": {"type": "m.space"}},
+					{"type": "m.room.join_rules", "state_key": "", "content": {"join_rule": "public"}},
+					{"type": "m.space.child", "state_key": "!a:matrix.org", "content": {"via": ["matrix.org"]}},
+					{"type": "m.space.child", "state_key": "!b:matrix.org", "content": {"suggested": true}},
+					{"type": "m.space.child", "state_key": "!g:matrix.org", "content": {"via": ["matrix.org"]}},
+					{"type": "m.space.child", "state_key": "!r:remote.org", "content": {"via": ["remote.org"]}},
+					{"type": "m.space.child", "state_key": "!t:matrix.org", "content": {"via": ["matrix.org"]}}
+				]}`),
+				"/_synapse/admin/v1/rooms/!e:matrix.org/state": []byte(`{"state": [
+					{"type": "m.room.create", "state_key": "", "content": {"type": "m.space"}}
+				]}`),
+				"/_synapse/admin/v1/rooms/!a:matrix.org/state": []byte(`{"state": [
+					{"type": "m.room.join_rules", "state_key": "", "content": {"join_rule": "invite"}},
+					{"type": "m.space.parent", "state_key": "!s:matrix.org", "content": {"via": ["matrix.org"]}}
+				]}`),
+				"/_synapse/admin/v1/rooms/!t:matrix.org/state": []byte(`{"state": [
+					{"type": "m.room.create", "state_key": "", "content": {"type": "m.space"}},
+					{"type": "m.room.join_rules", "state_key": "", "content": {"join_rule": "public"}},
+					{"type": "m.space.parent", "state_key": "!s:matrix.org", "content": {"via": ["matrix.org"]}},
+					{"type": "m.space.child", "state_key": "!s:matrix.org", "content": {"via": ["matrix.org"]}}
+				]}`),
+			},
+			errors: map[string]error{
+				"/_synapse/admin/v1/rooms/!g:matrix.org/state": notFound,
+				"/_synapse/admin/v1/rooms/!r:remote.org/state": notFound,
+			},
+			wantChecks: map[string]int{
+				LintStricterJoinRule: 1,
+				LintMissingVia:       1,
+				LintDanglingChild:    1,
+				LintUnjoinedChild:    1,
+				LintMissingParent:    1,
+				LintCycle:            1,
+				LintEmptySpace:       1,
+			},
+		},
+		{
+			name:   "healthy hierarchy",
+			spaces: []Space{{ID: "!s:matrix.org"}},
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/rooms/!s:matrix.org/state": []byte(`{"state": [
+					{"type": "m.room.create", "state_key": "", "content": {"type": "m.space"}},
+					{"type": "m.room.join_rules", "state_key": "", "content": {"join_rule": "invite"}},
+					{"type": "m.space.child", "state_key": "!a:matrix.org", "content": {"via": ["matrix.org"]}}
+				]}`),
+				"/_synapse/admin/v1/rooms/!a:matrix.org/state": []byte(`{"state": [
+					{"type": "m.room.join_rules", "state_key": "", "content": {"join_rule": "restricted", "allow": [{"type": "m.room_membership", "room_id": "!s:matrix.org"}]}},
+					{"type": "m.space.parent", "state_key": "!s:matrix.org", "content": {"via": ["matrix.org"]}}
+				]}`),
+			},
+			wantChecks: map[string]int{},
+		},
+		{
+			name:   "removed child and parent are ignored",
+			spaces: []Space{{ID: "!s:matrix.org"}},
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/rooms/!s:matrix.org/state": []byte(`{"state": [
+					{"type": "m.room.create", "state_key": "", "content": {"type": "m.space"}},
+					{"type": "m.space.child", "state_key": "!old:matrix.org", "content": {}},
+					{"type": "m.space.child", "state_key": "!a:matrix.org", "content": {"via": ["matrix.org"]}}
+				]}`),
+				"/_synapse/admin/v1/rooms/!a:matrix.org/state": []byte(`{"state": [
+					{"type": "m.space.parent", "state_key": "!s:matrix.org", "content": {}}
+				]}`),
+			},
+			wantChecks: map[string]int{LintMissingParent: 1},
+		},
+		{
+			name:   "missing child without a server name is unjoined",
+			spaces: []Space{{ID: "!s:matrix.org"}},
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/rooms/!s:matrix.org/state": []byte(`{"state": [
+					{"type": "m.room.create", "state_key": "", "content": {"type": "m.space"}},
+					{"type": "m.space.child", "state_key": "!g:matrix.org", "content": {"via": ["matrix.org"]}}
+				]}`),
+			},
+			errors: map[string]error{
+				"/_matrix/client/v3/account/whoami":            assert.AnError,
+				"/_synapse/admin/v1/rooms/!g:matrix.org/state": notFound,
+			},
+			wantChecks: map[string]int{LintUnjoinedChild: 1},
+		},
+		{
+			name:   "malformed child is reported, not fatal",
+			spaces: []Space{{ID: "!s:matrix.org"}},
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/rooms/!s:matrix.org/state": []byte(`{"state": [
+					{"type": "m.room.create", "state_key": "", "content": {"type": "m.space"}},
+					{"type": "m.space.child", "state_key": "!bad:matrix.org", "content": {"via": "matrix.org"}},
+					{"type": "m.space.child", "state_key": "!a:matrix.org", "content": {"via": ["matrix.org"]}}
+				]}`),
+				"/_synapse/admin/v1/rooms/!a:matrix.org/state": []byte(`{"state": [
+					{"type": "m.space.parent", "state_key": "!s:matrix.org", "content": {"via": ["matrix.org"]}}
+				]}`),
+			},
+			wantChecks: map[string]int{LintMissingVia: 1},
+		},
+		{
+			name:   "state request fails",
+			spaces: []Space{{ID: "!s:matrix.org"}},
+			errors: map[string]error{
+				"/_synapse/admin/v1/rooms/!s:matrix.org/state": assert.AnError,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			issues, err := LintSpaces(context.Background(), mock, logrus.New(), tc.spaces)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			got := make(map[string]int)
+			for _, issue := range issues {
+				got[issue.Check]++
+			}
+			assert.Equal(t, tc.wantChecks, got)
+		})
+	}
+}
@@ -0,0 +1,318 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// ParseMediaID splits an mxc:// URI or a "<server>/<media_id>" string into its
+// server name and media ID.
+func ParseMediaID(s string) (string, string, error) {
+	server, mediaID, ok := strings.Cut(strings.TrimPrefix(s, "mxc://"), "/")
+	if !ok || server == "" || mediaID == "" || strings.Contains(mediaID, "/") {
+		return "", "", fmt.Errorf("invalid media ID %q: expected mxc://<server>/<media_id> or <server>/<media_id>", s)
+	}
+	return server, mediaID, nil
+}
+
+type quarantineResponse struct {
+	NumQuarantined int `json:"num_quarantined"`
+}
+
+// QuarantineMedia quarantines a single media item.
+func QuarantineMedia(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, server string, mediaID string) error {
+	logger.WithFields(logrus.Fields{
+		"event":  "quarantine_media",
+		"server": server,
+		"media":  mediaID,
+	}).Debug("Quarantining media")
+	_, err := client.Call(ctx, "/_synapse/admin/v1/media/quarantine/"+server+"/"+mediaID, "POST", nil, false)
+	return err
+}
+
+// UnquarantineMedia removes a single media item from quarantine.
+func UnquarantineMedia(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, server string, mediaID string) error {
+	logger.WithFields(logrus.Fields{
+		"event":  "unquarantine_media",
+		"server": server,
+		"media":  mediaID,
+	}).Debug("Removing media from quarantine")
+	_, err := client.Call(ctx, "/_synapse/admin/v1/media/unquarantine/"+server+"/"+mediaID, "POST", nil, false)
+	return err
+}
+
+// QuarantineRoomMedia quarantines all media in a room and returns the number of quarantined items.
+func QuarantineRoomMedia(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, roomID string) (int, error) {
+	logger.WithFields(logrus.Fields{
+		"event": "quarantine_room_media",
+		"room":  roomID,
+	}).Debug("Quarantining all media in room")
+	return quarantineMany(ctx, client, "/_synapse/admin/v1/room/"+roomID+"/media/quarantine")
+}
+
+// QuarantineUserMedia quarantines all media uploaded by a user and returns the number of quarantined items.
+func QuarantineUserMedia(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, userID string) (int, error) {
+	logger.WithFields(logrus.Fields{
+		"event": "quarantine_user_media",
+		"user":  userID,
+	}).Debug("Quarantining all media of user")
+	return quarantineMany(ctx, client, "/_synapse/admin/v1/user/"+userID+"/media/quarantine")
+}
+
+// ProtectMedia exempts a local media item from quarantine.
+func ProtectMedia(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, mediaID string) error {
+	logger.WithFields(logrus.Fields{
+		"event": "protect_media",
+		"media": mediaID,
+	}).Debug("Protecting media from quarantine")
+	_, err := client.Call(ctx, "/_synapse/admin/v1/media/protect/"+mediaID, "POST", nil, false)
+	return err
+}
+
+func quarantineMany(ctx context.Context, client SynapseClientInterface, path string) (int, error) {
+	output, err := client.Call(ctx, path, "POST", nil, false)
+	if err != nil {
+		return 0, err
+	}
+	var resp quarantineResponse
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return 0, fmt.Errorf("failed to parse quarantine response: %w", err)
+	}
+	return resp.NumQuarantined, nil
+}
+
+type purgeMediaCacheResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// PurgeRemoteMediaCache deletes cached remote media last accessed before the
+// cutoff and returns the number of deleted items.
+func PurgeRemoteMediaCache(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, before time.Time) (int, error) {
+	logger.WithFields(logrus.Fields{
+		"event":     "purge_remote_media_cache",
+		"before_ts": before.UnixMilli(),
+	}).Debug("Purging remote media cache")
+	output, err := client.Call(ctx, "/_synapse/admin/v1/purge_media_cache?before_ts="+strconv.FormatInt(before.UnixMilli(), 10), "POST", nil, false)
+	if err != nil {
+		return 0, err
+	}
+	var resp purgeMediaCacheResponse
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return 0, fmt.Errorf("failed to parse purge media cache response: %w", err)
+	}
+	return resp.Deleted, nil
+}
+
+// DeleteLocalMediaQuery selects the local media removed by DeleteLocalMedia.
+type DeleteLocalMediaQuery struct {
+	Before time.Time
+	// SizeGT only deletes media larger than this many bytes. Zero deletes any size.
+	SizeGT int64
+	// KeepProfiles spares media used as user or room avatars. It is always
+	// sent because Synapse keeps them when the parameter is missing.
+	KeepProfiles bool
+}
+
+type deleteMediaResponse struct {
+	DeletedMedia []string `json:"deleted_media"`
+	Total        int      `json:"total"`
+}
+
+// DeleteLocalMedia deletes local media last accessed before the cutoff and
+// returns the number of deleted items.
+func DeleteLocalMedia(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, query DeleteLocalMediaQuery) (int, error) {
+	params := url.Values{}
+	params.Set("before_ts", strconv.FormatInt(query.Before.UnixMilli(), 10))
+	if query.SizeGT > 0 {
+		params.Set("size_gt", strconv.FormatInt(query.SizeGT, 10))
+	}
+	params.Set("keep_profiles", strconv.FormatBool(query.KeepProfiles))
+	logger.WithFields(logrus.Fields{
+		"event":  "delete_local_media",
+		"params": params.Encode(),
+	}).Debug("Deleting local media")
+	return deleteMedia(ctx, client, "/_synapse/admin/v1/media/delete?"+params.Encode(), "POST")
+}
+
+// DeleteMedia deletes a single local media item and returns the number of deleted items.
+func DeleteMedia(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, server string, mediaID string) (int, error) {
+	logger.WithFields(logrus.Fields{
+		"event":  "delete_media",
+		"server": server,
+		"media":  mediaID,
+	}).Debug("Deleting media")
+	return deleteMedia(ctx, client, "/_synapse/admin/v1/media/"+server+"/"+mediaID, "DELETE")
+}
+
+func deleteMedia(ctx context.Context, client SynapseClientInterface, path string, method string) (int, error) {
+	output, err := client.Call(ctx, path, method, nil, false)
+	if err != nil {
+		return 0, err
+	}
+	var resp deleteMediaResponse
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return 0, fmt.Errorf("failed to parse delete media response: %w", err)
+	}
+	return resp.Total, nil
+}
+
+// MediaInfo is the admin view of a single media item.
+type MediaInfo struct {
+	URI                string `json:"-"`
+	Local              bool   `json:"-"`
+	MediaOrigin        string `json:"media_origin"`
+	MediaID            string `json:"media_id"`
+	MediaType          string `json:"media_type"`
+	MediaLength        int64  `json:"media_length"`
+	UploadName         string `json:"upload_name"`
+	UserID             string `json:"user_id"`
+	CreatedTS          int64  `json:"created_ts"`
+	LastAccessTS       int64  `json:"last_access_ts"`
+	QuarantinedBy      string `json:"quarantined_by"`
+	SafeFromQuarantine bool   `json:"safe_from_quarantine"`
+}
+
+func (m MediaInfo) Header() []string {
+	return []string{"MXC URI", "Location", "Size", "Content Type", "Uploader", "Quarantine"}
+}
+
+func (m MediaInfo) Row() []interface{} {
+	location := "remote"
+	if m.Local {
+		location = "local"
+	}
+	quarantine := "no"
+	switch {
+	case m.QuarantinedBy != "":
+		quarantine = "quarantined by " + m.QuarantinedBy
+	case m.SafeFromQuarantine:
+		quarantine = "protected"
+	}
+	return []interface{}{m.URI, location, internal.HumanBytes(m.MediaLength), m.MediaType, m.UserID, quarantine}
+}
+
+type mediaInfoResponse struct {
+	MediaInfo MediaInfo `json:"media_info"`
+}
+
+// GetMediaInfo retrieves the details of a single local or cached remote media item.
+func GetMediaInfo(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, server string, mediaID string) (MediaInfo, error) {
+	logger.WithFields(logrus.Fields{
+		"event":  "get_media_info",
+		"server": server,
+		"media":  mediaID,
+	}).Debug("Fetching media info")
+	output, err := client.Call(ctx, "/_synapse/admin/v1/media/"+server+"/"+mediaID, "GET", nil, false)
+	if err != nil {
+		return MediaInfo{}, err
+	}
+	var resp mediaInfoResponse
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return MediaInfo{}, fmt.Errorf("failed to parse media info of mxc://%s/%s: %w", server, mediaID, err)
+	}
+	info := resp.MediaInfo
+	info.URI = "mxc://" + server + "/" + mediaID
+	info.Local = info.MediaOrigin == ""
+	return info, nil
+}
+
+type roomMediaResponse struct {
+	Local  []string `json:"local"`
+	Remote []string `json:"remote"`
+}
+
+// GetRoomMedia lists the media referenced by a room together with their details.
+// Media that no longer exists on the server is listed with its URI only.
+func GetRoomMedia(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, roomID string) ([]MediaInfo, error) {
+	output, err := client.Call(ctx, "/_synapse/admin/v1/room/"+roomID+"/media", "GET", nil, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp roomMediaResponse
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse media of room %s: %w", roomID, err)
+	}
+
+	media := make([]MediaInfo, 0, len(resp.Local)+len(resp.Remote))
+	for _, uri := range resp.Local {
+		media = append(media, MediaInfo{URI: uri, Local: true})
+	}
+	for _, uri := range resp.Remote {
+		media = append(media, MediaInfo{URI: uri})
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	var mu sync.Mutex
+	sem := make(chan struct{}, maxConcurrentRequests)
+
+	logger.WithFields(logrus.Fields{
+		"event": "fetching_room_media_info",
+		"room":  roomID,
+		"count": len(media),
+	}).Debug("Fetching details for room media")
+
+	for i := range media {
+		g.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+
+			defer func() { <-sem }()
+
+			server, mediaID, err := ParseMediaID(media[i].URI)
+			if err != nil {
+				return err
+			}
+			info, err := GetMediaInfo(gctx, client, logger, server, mediaID)
+			if IsNotFound(err) {
+				logger.WithFields(logrus.Fields{
+					"event": "room_media_not_found",
+					"media": media[i].URI,
+				}).Warn("Media referenced by room no longer exists")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			info.Local = media[i].Local
+
+			mu.Lock()
+			media[i] = info
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
@@ -0,0 +1,305 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMediaID(t *testing.T) {
+	cases := []struct {
+		input      string
+		wantServer string
+		wantID     string
+		wantErr    bool
+	}{
+		{input: "mxc://matrix.org/abc123", wantServer: "matrix.org", wantID: "abc123"},
+		{input: "matrix.org/abc123", wantServer: "matrix.org", wantID: "abc123"},
+		{input: "abc123", wantErr: true},
+		{input: "mxc://matrix.org/", wantErr: true},
+		{input: "mxc://matrix.org/a/b", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			server, mediaID, err := ParseMediaID(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantServer, server)
+			assert.Equal(t, tc.wantID, mediaID)
+		})
+	}
+}
+
+func TestQuarantineMedia(t *testing.T) {
+	cases := []struct {
+		name      string
+		call      func(client SynapseClientInterface) (int, error)
+		responses map[string][]byte
+		errors    map[string]error
+		wantErr   bool
+		wantCount int
+	}{
+		{
+			name: "single media",
+			call: func(client SynapseClientInterface) (int, error) {
+				return 0, QuarantineMedia(context.Background(), client, logrus.New(), "matrix.org", "abc")
+			},
+			responses: map[string][]byte{"/_synapse/admin/v1/media/quarantine/matrix.org/abc": []byte(`{}`)},
+		},
+		{
+			name: "unquarantine single media",
+			call: func(client SynapseClientInterface) (int, error) {
+				return 0, UnquarantineMedia(context.Background(), client, logrus.New(), "matrix.org", "abc")
+			},
+			responses: map[string][]byte{"/_synapse/admin/v1/media/unquarantine/matrix.org/abc": []byte(`{}`)},
+		},
+		{
+			name: "room media",
+			call: func(client SynapseClientInterface) (int, error) {
+				return QuarantineRoomMedia(context.Background(), client, logrus.New(), "!room:matrix.org")
+			},
+			responses: map[string][]byte{"/_synapse/admin/v1/room/!room:matrix.org/media/quarantine": []byte(`{"num_quarantined": 7}`)},
+			wantCount: 7,
+		},
+		{
+			name: "user media",
+			call: func(client SynapseClientInterface) (int, error) {
+				return QuarantineUserMedia(context.Background(), client, logrus.New(), "@spam:matrix.org")
+			},
+			responses: map[string][]byte{"/_synapse/admin/v1/user/@spam:matrix.org/media/quarantine": []byte(`{"num_quarantined": 3}`)},
+			wantCount: 3,
+		},
+		{
+			name: "protect media",
+			call: func(client SynapseClientInterface) (int, error) {
+				return 0, ProtectMedia(context.Background(), client, logrus.New(), "abc")
+			},
+			responses: map[string][]byte{"/_synapse/admin/v1/media/protect/abc": []byte(`{}`)},
+		},
+		{
+			name: "room media request fails",
+			call: func(client SynapseClientInterface) (int, error) {
+				return QuarantineRoomMedia(context.Background(), client, logrus.New(), "!room:matrix.org")
+			},
+			errors:  map[string]error{"/_synapse/admin/v1/room/!room:matrix.org/media/quarantine": assert.AnError},
+			wantErr: true,
+		},
+		{
+			name: "malformed user response",
+			call: func(client SynapseClientInterface) (int, error) {
+				return QuarantineUserMedia(context.Background(), client, logrus.New(), "@spam:matrix.org")
+			},
+			responses: map[string][]byte{"/_synapse/admin/v1/user/@spam:matrix.org/media/quarantine": []byte(`{"num_quarantined": `)},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			count, err := tc.call(mock)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantCount, count)
+		})
+	}
+}
+
+func TestPurgeRemoteMediaCache(t *testing.T) {
+	before := time.UnixMilli(1700000000000)
+	path := "/_synapse/admin/v1/purge_media_cache?before_ts=1700000000000"
+
+	cases := []struct {
+		name        string
+		responses   map[string][]byte
+		errors      map[string]error
+		wantErr     bool
+		wantDeleted int
+	}{
+		{
+			name:        "deleted items",
+			responses:   map[string][]byte{path: []byte(`{"deleted": 42}`)},
+			wantDeleted: 42,
+		},
+		{
+			name:    "request fails",
+			errors:  map[string]error{path: assert.AnError},
+			wantErr: true,
+		},
+		{
+			name:      "malformed response",
+			responses: map[string][]byte{path: []byte(`{"deleted": "x"}`)},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			deleted, err := PurgeRemoteMediaCache(context.Background(), mock, logrus.New(), before)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantDeleted, deleted)
+		})
+	}
+}
+
+func TestDeleteLocalMedia(t *testing.T) {
+	before := time.UnixMilli(1700000000000)
+
+	cases := []struct {
+		name      string
+		query     DeleteLocalMediaQuery
+		responses map[string][]byte
+		errors    map[string]error
+		wantErr   bool
+		wantTotal int
+	}{
+		{
+			name:      "age only deletes profile media",
+			query:     DeleteLocalMediaQuery{Before: before},
+			responses: map[string][]byte{"/_synapse/admin/v1/media/delete?before_ts=1700000000000&keep_profiles=false": []byte(`{"deleted_media": ["a", "b"], "total": 2}`)},
+			wantTotal: 2,
+		},
+		{
+			name:      "size and profiles",
+			query:     DeleteLocalMediaQuery{Before: before, SizeGT: 10485760, KeepProfiles: true},
+			responses: map[string][]byte{"/_synapse/admin/v1/media/delete?before_ts=1700000000000&keep_profiles=true&size_gt=10485760": []byte(`{"deleted_media": ["a"], "total": 1}`)},
+			wantTotal: 1,
+		},
+		{
+			name:    "request fails",
+			query:   DeleteLocalMediaQuery{Before: before},
+			errors:  map[string]error{"/_synapse/admin/v1/media/delete?before_ts=1700000000000&keep_profiles=false": assert.AnError},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			total, err := DeleteLocalMedia(context.Background(), mock, logrus.New(), tc.query)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantTotal, total)
+		})
+	}
+}
+
+func TestDeleteMedia(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{
+		"/_synapse/admin/v1/media/matrix.org/abc": []byte(`{"deleted_media": ["abc"], "total": 1}`),
+	}}
+	total, err := DeleteMedia(context.Background(), mock, logrus.New(), "matrix.org", "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	_, err = DeleteMedia(context.Background(), mock, logrus.New(), "matrix.org", "missing")
+	assert.Error(t, err)
+}
+
+func TestGetMediaInfo(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{
+		"/_synapse/admin/v1/media/matrix.org/abc": []byte(`{"media_info": {"media_id": "abc", "media_type": "image/png", "media_length": 2048, "user_id": "@alice:matrix.org", "quarantined_by": "@admin:matrix.org"}}`),
+		"/_synapse/admin/v1/media/remote.org/xyz": []byte(`{"media_info": {"media_origin": "remote.org", "media_id": "xyz", "media_type": "video/mp4", "media_length": 10}}`),
+		"/_synapse/admin/v1/media/matrix.org/bad": []byte(`{"media_info": `),
+	}}
+
+	info, err := GetMediaInfo(context.Background(), mock, logrus.New(), "matrix.org", "abc")
+	assert.NoError(t, err)
+	assert.True(t, info.Local)
+	assert.Equal(t, []interface{}{"mxc://matrix.org/abc", "local", "2.0 KB", "image/png", "@alice:matrix.org", "quarantined by @admin:matrix.org"}, info.Row())
+
+	info, err = GetMediaInfo(context.Background(), mock, logrus.New(), "remote.org", "xyz")
+	assert.NoError(t, err)
+	assert.False(t, info.Local)
+	assert.Equal(t, []interface{}{"mxc://remote.org/xyz", "remote", "10 B", "video/mp4", "", "no"}, info.Row())
+
+	_, err = GetMediaInfo(context.Background(), mock, logrus.New(), "matrix.org", "bad")
+	assert.Error(t, err)
+}
+
+func TestGetRoomMedia(t *testing.T) {
+	cases := []struct {
+		name      string
+		responses map[string][]byte
+		errors    map[string]error
+		wantErr   bool
+		wantTypes []string
+	}{
+		{
+			name: "local, remote and deleted media",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/room/!r:matrix.org/media": []byte(`{"local": ["mxc://matrix.org/abc", "mxc://matrix.org/gone"], "remote": ["mxc://remote.org/xyz"]}`),
+				"/_synapse/admin/v1/media/matrix.org/abc":     []byte(`{"media_info": {"media_id": "abc", "media_type": "image/png", "media_length": 1}}`),
+				"/_synapse/admin/v1/media/remote.org/xyz":     []byte(`{"media_info": {"media_origin": "remote.org", "media_id": "xyz", "media_type": "video/mp4", "media_length": 2}}`),
+			},
+			errors: map[string]error{
+				"/_synapse/admin/v1/media/matrix.org/gone": &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+			},
+			wantTypes: []string{"image/png", "", "video/mp4"},
+		},
+		{
+			name: "media info fails",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/room/!r:matrix.org/media": []byte(`{"local": ["mxc://matrix.org/abc"], "remote": []}`),
+			},
+			errors: map[string]error{
+				"/_synapse/admin/v1/media/matrix.org/abc": assert.AnError,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			media, err := GetRoomMedia(context.Background(), mock, logrus.New(), "!r:matrix.org")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			types := make([]string, 0)
+			for _, m := range media {
+				types = append(types, m.MediaType)
+			}
+			assert.Equal(t, tc.wantTypes, types)
+			assert.True(t, media[0].Local)
+			assert.True(t, media[1].Local)
+			assert.False(t, media[2].Local)
+		})
+	}
+}
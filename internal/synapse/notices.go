@@ -0,0 +1,154 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+	"maunium.net/go/mautrix/format"
+)
+
+// Notice is the content of a server notice.
+type Notice struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format,omitempty"`
+	FormattedBody string `json:"formatted_body,omitempty"`
+}
+
+// NewNotice renders a markdown message into a notice with a plain text body
+// and, when the markdown produces formatting, an HTML formatted body.
+func NewNotice(markdown string) Notice {
+	content := format.RenderMarkdown(markdown, true, false)
+	return Notice{
+		MsgType:       "m.text",
+		Body:          content.Body,
+		Format:        string(content.Format),
+		FormattedBody: content.FormattedBody,
+	}
+}
+
+// Fingerprint returns a SHA-256 hash of the rendered notice, identifying it
+// in the progress record of a broadcast.
+func (n Notice) Fingerprint() string {
+	data, _ := json.Marshal(n)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+type serverNoticeRequest struct {
+	UserID  string `json:"user_id"`
+	Content Notice `json:"content"`
+}
+
+// SendServerNotice sends a notice to a single user.
+func SendServerNotice(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, userID string, notice Notice) error {
+	payload, err := json.Marshal(serverNoticeRequest{UserID: userID, Content: notice})
+	if err != nil {
+		return err
+	}
+	logger.WithFields(logrus.Fields{
+		"event": "send_server_notice",
+		"user":  userID,
+	}).Debug("Sending server notice")
+	_, err = client.Call(ctx, "/_synapse/admin/v1/send_server_notice", "POST", payload, false)
+	return err
+}
+
+// BroadcastResult summarizes a BroadcastServerNotice run.
+type BroadcastResult struct {
+	Sent    int
+	Skipped int
+	// Failed lists users the server refused to send the notice to.
+	Failed []string
+}
+
+// BroadcastServerNotice sends a notice to every user with at most concurrency
+// requests in flight. Users already in progress are skipped and every
+// successful send is recorded, so an interrupted broadcast can be resumed.
+// Users rejected by the server with a client error are reported in the result;
+// any other error, including rate limiting, stops the broadcast.
+func BroadcastServerNotice(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, users []string, notice Notice, concurrency int, progress *internal.ProgressRecord) (BroadcastResult, error) {
+	var result BroadcastResult
+	if concurrency < 1 {
+		return result, fmt.Errorf("concurrency must be at least 1")
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	var mu sync.Mutex
+	sem := make(chan struct{}, concurrency)
+
+	logger.WithFields(logrus.Fields{
+		"event":       "broadcast_server_notice",
+		"users":       len(users),
+		"concurrency": concurrency,
+	}).Debug("Broadcasting server notice")
+
+	for _, user := range users {
+		if progress.Done(user) {
+			result.Skipped++
+			continue
+		}
+
+		g.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+
+			defer func() { <-sem }()
+
+			err := SendServerNotice(gctx, client, logger, user, notice)
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) && statusErr.StatusCode >= 400 && statusErr.StatusCode < 500 && statusErr.StatusCode != http.StatusTooManyRequests {
+				logger.WithFields(logrus.Fields{
+					"event": "server_notice_rejected",
+					"user":  user,
+					"error": err,
+				}).Warn("Server notice rejected for user")
+				mu.Lock()
+				result.Failed = append(result.Failed, user)
+				mu.Unlock()
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := progress.Record(user); err != nil {
+				return err
+			}
+
+			mu.Lock()
+			result.Sent++
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	return result, err
+}
@@ -0,0 +1,130 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// noticeClient answers send_server_notice requests based on the recipient.
+type noticeClient struct {
+	mu     sync.Mutex
+	errors map[string]error
+	sent   []string
+}
+
+func (n *noticeClient) Call(ctx context.Context, path string, method string, payload []byte, retry bool) ([]byte, error) {
+	var req serverNoticeRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, err
+	}
+	if err, ok := n.errors[req.UserID]; ok {
+		return nil, err
+	}
+	n.mu.Lock()
+	n.sent = append(n.sent, req.UserID)
+	n.mu.Unlock()
+	return []byte(`{"event_id": "$e"}`), nil
+}
+
+func TestNoticeFingerprint(t *testing.T) {
+	assert.Equal(t, NewNotice("hello").Fingerprint(), NewNotice("hello").Fingerprint())
+	assert.NotEqual(t, NewNotice("hello").Fingerprint(), NewNotice("hello **world**").Fingerprint())
+	assert.Len(t, NewNotice("hello").Fingerprint(), 64)
+}
+
+func TestNewNotice(t *testing.T) {
+	plain := NewNotice("Maintenance tonight")
+	assert.Equal(t, Notice{MsgType: "m.text", Body: "Maintenance tonight"}, plain)
+
+	formatted := NewNotice("Maintenance **tonight**")
+	assert.Equal(t, "org.matrix.custom.html", formatted.Format)
+	assert.Equal(t, "Maintenance <strong>tonight</strong>", formatted.FormattedBody)
+}
+
+func TestBroadcastServerNotice(t *testing.T) {
+	users := []string{"@a:matrix.org", "@b:matrix.org", "@c:matrix.org", "@bot:matrix.org"}
+
+	cases := []struct {
+		name        string
+		errors      map[string]error
+		alreadySent []string
+		wantErr     bool
+		wantSent    int
+		wantSkipped int
+		wantFailed  []string
+	}{
+		{
+			name:     "send to everyone",
+			wantSent: 4,
+		},
+		{
+			name:        "resume skips delivered users and reports rejections",
+			alreadySent: []string{"@a:matrix.org", "@b:matrix.org"},
+			errors: map[string]error{
+				"@bot:matrix.org": &StatusError{URL: "test", StatusCode: http.StatusBadRequest, Status: "400 Bad Request"},
+			},
+			wantSent:    1,
+			wantSkipped: 2,
+			wantFailed:  []string{"@bot:matrix.org"},
+		},
+		{
+			name: "server error stops the broadcast",
+			errors: map[string]error{
+				"@c:matrix.org": &StatusError{URL: "test", StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "progress")
+			notice := NewNotice("hello")
+			progress, err := internal.OpenProgressRecord(path, notice.Fingerprint())
+			assert.NoError(t, err)
+			for _, user := range tc.alreadySent {
+				assert.NoError(t, progress.Record(user))
+			}
+
+			client := &noticeClient{errors: tc.errors}
+			result, err := BroadcastServerNotice(context.Background(), client, logrus.New(), users, notice, 2, progress)
+			assert.NoError(t, progress.Close())
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantSent, result.Sent)
+			assert.Equal(t, tc.wantSkipped, result.Skipped)
+			assert.Equal(t, tc.wantFailed, result.Failed)
+
+			resumed, err := internal.OpenProgressRecord(path, notice.Fingerprint())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantSent+tc.wantSkipped, resumed.Len())
+			assert.NoError(t, resumed.Close())
+		})
+	}
+}
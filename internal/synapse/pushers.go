@@ -0,0 +1,67 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Pusher is a push gateway or email notification target registered by a user.
+type Pusher struct {
+	AppDisplayName    string     `json:"app_display_name"`
+	AppID             string     `json:"app_id"`
+	Data              PusherData `json:"data"`
+	DeviceDisplayName string     `json:"device_display_name"`
+	Kind              string     `json:"kind"`
+	Lang              string     `json:"lang"`
+	ProfileTag        string     `json:"profile_tag"`
+	PushKey           string     `json:"pushkey"`
+}
+
+// PusherData holds the kind specific settings of a pusher.
+type PusherData struct {
+	URL    string `json:"url"`
+	Format string `json:"format"`
+}
+
+func (p Pusher) Header() []string {
+	return []string{"App", "App ID", "Device", "Kind", "Push Key", "URL", "Lang"}
+}
+
+func (p Pusher) Row() []interface{} {
+	return []interface{}{p.AppDisplayName, p.AppID, p.DeviceDisplayName, p.Kind, p.PushKey, p.Data.URL, p.Lang}
+}
+
+type pushersResponse struct {
+	Pushers []Pusher `json:"pushers"`
+	Total   int      `json:"total"`
+}
+
+// GetPushers returns the pushers registered by a user.
+func GetPushers(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, userID string) ([]Pusher, error) {
+	path := "/_synapse/admin/v1/users/" + userID + "/pushers"
+	logger.WithFields(logrus.Fields{
+		"event": "get_pushers",
+		"path":  path,
+	}).Debug("Fetching pushers")
+	var resp pushersResponse
+	if err := callJSON(ctx, client, path, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Pushers, nil
+}
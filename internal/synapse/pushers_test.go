@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPushers(t *testing.T) {
+	cases := []struct {
+		name      string
+		responses map[string][]byte
+		errors    map[string]error
+		wantErr   bool
+		wantRows  [][]interface{}
+	}{
+		{
+			name: "http and email pushers",
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/users/@a:matrix.org/pushers": []byte(`{"pushers": [
+					{"app_display_name": "Element", "app_id": "im.vector.app.android", "data": {"url": "https://push.example.org/_matrix/push/v1/notify", "format": "event_id_only"}, "device_display_name": "Pixel", "kind": "http", "lang": "en", "pushkey": "abc"},
+					{"app_display_name": "Email Notifications", "app_id": "m.email", "data": {}, "device_display_name": "alice@example.org", "kind": "email", "lang": "en", "pushkey": "alice@example.org"}
+				], "total": 2}`),
+			},
+			wantRows: [][]interface{}{
+				{"Element", "im.vector.app.android", "Pixel", "http", "abc", "https://push.example.org/_matrix/push/v1/notify", "en"},
+				{"Email Notifications", "m.email", "alice@example.org", "email", "alice@example.org", "", "en"},
+			},
+		},
+		{
+			name:      "no pushers",
+			responses: map[string][]byte{"/_synapse/admin/v1/users/@a:matrix.org/pushers": []byte(`{"pushers": [], "total": 0}`)},
+			wantRows:  [][]interface{}{},
+		},
+		{
+			name: "unknown user",
+			errors: map[string]error{
+				"/_synapse/admin/v1/users/@a:matrix.org/pushers": &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			pushers, err := GetPushers(context.Background(), mock, logrus.New(), "@a:matrix.org")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			rows := make([][]interface{}, 0)
+			for _, p := range pushers {
+				rows = append(rows, p.Row())
+			}
+			assert.Equal(t, tc.wantRows, rows)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RedactUserQuery restricts which events of a user are redacted.
+type RedactUserQuery struct {
+	// Rooms limits the redaction to these rooms. Empty means every room the user is a member of.
+	Rooms  []string
+	Reason string
+	// Limit caps the number of events redacted per room. Zero uses the server default.
+	Limit int
+}
+
+type redactUserRequest struct {
+	Rooms  []string `json:"rooms"`
+	Reason string   `json:"reason,omitempty"`
+	Limit  int      `json:"limit,omitempty"`
+}
+
+// Redaction task states reported by the redact status endpoint.
+const (
+	RedactScheduled = "scheduled"
+	RedactActive    = "active"
+	RedactFailed    = "failed"
+)
+
+// RedactStatus is the progress of a user redaction task.
+type RedactStatus struct {
+	Status string `json:"status"`
+	// FailedRedactions maps event IDs to the reason their redaction failed.
+	FailedRedactions map[string]string `json:"failed_redactions"`
+}
+
+// Done reports whether the task is no longer scheduled or running.
+func (r RedactStatus) Done() bool {
+	return r.Status != RedactScheduled && r.Status != RedactActive
+}
+
+// FailedRedaction is an event that could not be redacted.
+type FailedRedaction struct {
+	EventID string
+	Error   string
+}
+
+func (f FailedRedaction) Header() []string {
+	return []string{"Event ID", "Error"}
+}
+
+func (f FailedRedaction) Row() []interface{} {
+	return []interface{}{f.EventID, f.Error}
+}
+
+// Failed returns the events that could not be redacted, sorted by event ID.
+func (r RedactStatus) Failed() []FailedRedaction {
+	failed := make([]FailedRedaction, 0, len(r.FailedRedactions))
+	for id, reason := range r.FailedRedactions {
+		failed = append(failed, FailedRedaction{EventID: id, Error: reason})
+	}
+	slices.SortFunc(failed, func(a, b FailedRedaction) int {
+		return strings.Compare(a.EventID, b.EventID)
+	})
+	return failed
+}
+
+// RedactUserEvents schedules the redaction of the events sent by userID and
+// returns the ID used to follow its progress.
+func RedactUserEvents(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, userID string, query RedactUserQuery) (string, error) {
+	rooms := query.Rooms
+	if rooms == nil {
+		rooms = []string{}
+	}
+	payload, err := json.Marshal(redactUserRequest{Rooms: rooms, Reason: query.Reason, Limit: query.Limit})
+	if err != nil {
+		return "", err
+	}
+	logger.WithFields(logrus.Fields{
+		"event": "redact_user_events",
+		"user":  userID,
+		"rooms": len(rooms),
+	}).Debug("Scheduling redaction of user events")
+	output, err := client.Call(ctx, "/_synapse/admin/v1/user/"+userID+"/redact", "POST", payload, false)
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		RedactID string `json:"redact_id"`
+	}
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse redaction response: %w", err)
+	}
+	return resp.RedactID, nil
+}
+
+// GetRedactStatus returns the progress of a redaction task.
+func GetRedactStatus(ctx context.Context, client SynapseClientInterface, redactID string) (RedactStatus, error) {
+	var status RedactStatus
+	err := callJSON(ctx, client, "/_synapse/admin/v1/user/redact_status/"+redactID, &status)
+	return status, err
+}
+
+// WaitForRedaction polls the redaction task every interval until it is done
+// or ctx is done, and returns its final status.
+func WaitForRedaction(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, redactID string, interval time.Duration) (RedactStatus, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := GetRedactStatus(ctx, client, redactID)
+		if err != nil {
+			return status, err
+		}
+		if status.Done() {
+			return status, nil
+		}
+		logger.WithFields(logrus.Fields{
+			"event":     "redaction_running",
+			"redact_id": redactID,
+			"status":    status.Status,
+			"failed":    len(status.FailedRedactions),
+		}).Info("Redaction still running")
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
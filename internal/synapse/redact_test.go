@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactUserEvents(t *testing.T) {
+	cases := []struct {
+		name        string
+		query       RedactUserQuery
+		errors      map[string]error
+		wantPayload string
+		wantErr     bool
+	}{
+		{
+			name:        "all rooms",
+			wantPayload: `{"rooms":[]}`,
+		},
+		{
+			name:        "selected rooms with reason",
+			query:       RedactUserQuery{Rooms: []string{"!a:matrix.org"}, Reason: "spam", Limit: 50},
+			wantPayload: `{"rooms":["!a:matrix.org"],"reason":"spam","limit":50}`,
+		},
+		{
+			name: "unknown user",
+			errors: map[string]error{
+				"/_synapse/admin/v1/user/@spam:matrix.org/redact": &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{
+				Responses: map[string][]byte{"/_synapse/admin/v1/user/@spam:matrix.org/redact": []byte(`{"redact_id": "abc"}`)},
+				Errors:    tc.errors,
+			}
+			id, err := RedactUserEvents(context.Background(), mock, logrus.New(), "@spam:matrix.org", tc.query)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "abc", id)
+			assert.JSONEq(t, tc.wantPayload, string(mock.Payloads["/_synapse/admin/v1/user/@spam:matrix.org/redact"]))
+		})
+	}
+}
+
+func TestWaitForRedaction(t *testing.T) {
+	active := []byte(`{"status": "active", "failed_redactions": {}}`)
+	done := []byte(`{"status": "complete", "failed_redactions": {"$b": "not found", "$a": "forbidden"}}`)
+
+	client := &sequenceClient{responses: [][]byte{active, active, done}}
+	status, err := WaitForRedaction(context.Background(), client, logrus.New(), "abc", time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, client.calls)
+	assert.Equal(t, []FailedRedaction{{EventID: "$a", Error: "forbidden"}, {EventID: "$b", Error: "not found"}}, status.Failed())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = WaitForRedaction(ctx, &sequenceClient{responses: [][]byte{active}}, logrus.New(), "abc", time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
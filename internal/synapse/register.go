@@ -0,0 +1,111 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SharedSecretRegistration holds the account to create with the
+// registration shared secret configured on the homeserver.
+type SharedSecretRegistration struct {
+	Username    string
+	Password    string
+	DisplayName string
+	Admin       bool
+	UserType    string
+}
+
+// RegisteredUser is the account created by RegisterWithSharedSecret.
+type RegisteredUser struct {
+	UserID      string `json:"user_id"`
+	AccessToken string `json:"access_token"`
+	HomeServer  string `json:"home_server"`
+	DeviceID    string `json:"device_id"`
+}
+
+type registerNonceResponse struct {
+	Nonce string `json:"nonce"`
+}
+
+type registerRequest struct {
+	Nonce       string `json:"nonce"`
+	Username    string `json:"username"`
+	DisplayName string `json:"displayname,omitempty"`
+	Password    string `json:"password"`
+	Admin       bool   `json:"admin"`
+	UserType    string `json:"user_type,omitempty"`
+	Mac         string `json:"mac"`
+}
+
+// RegisterWithSharedSecret creates an account without an access token by
+// signing a server issued nonce with the registration shared secret.
+func RegisterWithSharedSecret(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, sharedSecret string, reg SharedSecretRegistration) (RegisteredUser, error) {
+	var user RegisteredUser
+	var nonce registerNonceResponse
+	if err := callJSON(ctx, client, "/_synapse/admin/v1/register", &nonce); err != nil {
+		return user, err
+	}
+
+	payload, err := json.Marshal(registerRequest{
+		Nonce:       nonce.Nonce,
+		Username:    reg.Username,
+		DisplayName: reg.DisplayName,
+		Password:    reg.Password,
+		Admin:       reg.Admin,
+		UserType:    reg.UserType,
+		Mac:         registrationMac(sharedSecret, nonce.Nonce, reg),
+	})
+	if err != nil {
+		return user, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"event":    "register_user",
+		"username": reg.Username,
+		"admin":    reg.Admin,
+	}).Debug("Registering user with shared secret")
+	output, err := client.Call(ctx, "/_synapse/admin/v1/register", "POST", payload, false)
+	if err != nil {
+		return user, err
+	}
+	if err := json.Unmarshal(output, &user); err != nil {
+		return user, fmt.Errorf("failed to parse registration response: %w", err)
+	}
+	return user, nil
+}
+
+// registrationMac computes the HMAC-SHA1 Synapse expects over the nonce,
+// username, password, admin flag and optional user type.
+func registrationMac(sharedSecret string, nonce string, reg SharedSecretRegistration) string {
+	admin := "notadmin"
+	if reg.Admin {
+		admin = "admin"
+	}
+	mac := hmac.New(sha1.New, []byte(sharedSecret))
+	mac.Write([]byte(nonce + "\x00" + reg.Username + "\x00" + reg.Password + "\x00" + admin))
+	if reg.UserType != "" {
+		mac.Write([]byte("\x00" + reg.UserType))
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// registerClient answers the nonce request and records the registration payload.
+type registerClient struct {
+	postErr error
+	request registerRequest
+}
+
+func (r *registerClient) Call(ctx context.Context, path string, method string, payload []byte, retry bool) ([]byte, error) {
+	if path != "/_synapse/admin/v1/register" {
+		return nil, errors.New("unexpected path: " + path)
+	}
+	if method == http.MethodGet {
+		return []byte(`{"nonce": "abc"}`), nil
+	}
+	if err := json.Unmarshal(payload, &r.request); err != nil {
+		return nil, err
+	}
+	if r.postErr != nil {
+		return nil, r.postErr
+	}
+	return []byte(`{"access_token": "syt_token", "user_id": "@` + r.request.Username + `:matrix.org", "home_server": "matrix.org", "device_id": "DEV"}`), nil
+}
+
+func TestRegisterWithSharedSecret(t *testing.T) {
+	cases := []struct {
+		name    string
+		reg     SharedSecretRegistration
+		postErr error
+		wantMac string
+		wantErr bool
+	}{
+		{
+			name:    "admin",
+			reg:     SharedSecretRegistration{Username: "alice", Password: "pw", Admin: true},
+			wantMac: "dc5ed98b283077684552e3f72d67f26b3366bf58",
+		},
+		{
+			name:    "user type is signed",
+			reg:     SharedSecretRegistration{Username: "bot", Password: "pw", UserType: "bot"},
+			wantMac: "d03fd951ebbc14ef18e987fddf949517e37c581b",
+		},
+		{
+			name:    "rejected",
+			reg:     SharedSecretRegistration{Username: "alice", Password: "pw", Admin: true},
+			postErr: &StatusError{URL: "test", StatusCode: http.StatusForbidden, Status: "403 Forbidden"},
+			wantMac: "dc5ed98b283077684552e3f72d67f26b3366bf58",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &registerClient{postErr: tc.postErr}
+			user, err := RegisterWithSharedSecret(context.Background(), client, logrus.New(), "secret", tc.reg)
+			assert.Equal(t, "abc", client.request.Nonce)
+			assert.Equal(t, tc.wantMac, client.request.Mac)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "@"+tc.reg.Username+":matrix.org", user.UserID)
+			assert.Equal(t, "syt_token", user.AccessToken)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RegistrationToken is a token that allows registration on the homeserver.
+type RegistrationToken struct {
+	Token       string `json:"token"`
+	UsesAllowed *int   `json:"uses_allowed"`
+	Pending     int    `json:"pending"`
+	Completed   int    `json:"completed"`
+	// ExpiryTime is in milliseconds since the epoch, nil when the token never expires.
+	ExpiryTime *int64 `json:"expiry_time"`
+}
+
+func (r RegistrationToken) Header() []string {
+	return []string{"Token", "Uses Allowed", "Pending", "Completed", "Expiry"}
+}
+
+func (r RegistrationToken) Row() []interface{} {
+	usesAllowed := "unlimited"
+	if r.UsesAllowed != nil {
+		usesAllowed = strconv.Itoa(*r.UsesAllowed)
+	}
+	expiry := "never"
+	if r.ExpiryTime != nil {
+		expiry = time.UnixMilli(*r.ExpiryTime).UTC().Format(time.RFC3339)
+	}
+	return []interface{}{r.Token, usesAllowed, r.Pending, r.Completed, expiry}
+}
+
+// NewRegistrationToken holds the options for CreateRegistrationToken. Nil
+// fields are left for the server to default.
+type NewRegistrationToken struct {
+	Token       string `json:"token,omitempty"`
+	UsesAllowed *int   `json:"uses_allowed,omitempty"`
+	ExpiryTime  *int64 `json:"expiry_time,omitempty"`
+	Length      *int   `json:"length,omitempty"`
+}
+
+type registrationTokensResponse struct {
+	RegistrationTokens []RegistrationToken `json:"registration_tokens"`
+}
+
+// ListRegistrationTokens lists registration tokens. When valid is not nil only
+// valid (true) or only invalid (false) tokens are returned.
+func ListRegistrationTokens(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, valid *bool) ([]RegistrationToken, error) {
+	path := "/_synapse/admin/v1/registration_tokens"
+	if valid != nil {
+		path += "?valid=" + strconv.FormatBool(*valid)
+	}
+	logger.WithFields(logrus.Fields{
+		"event": "list_registration_tokens",
+		"path":  path,
+	}).Debug("Listing registration tokens")
+	var resp registrationTokensResponse
+	if err := callJSON(ctx, client, path, &resp); err != nil {
+		return nil, err
+	}
+	return resp.RegistrationTokens, nil
+}
+
+// CreateRegistrationToken creates a new registration token.
+func CreateRegistrationToken(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, req NewRegistrationToken) (RegistrationToken, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return RegistrationToken{}, err
+	}
+	logger.WithFields(logrus.Fields{
+		"event": "create_registration_token",
+	}).Debug("Creating registration token")
+	return sendRegistrationToken(ctx, client, "/_synapse/admin/v1/registration_tokens/new", "POST", payload)
+}
+
+// UpdateRegistrationToken changes the given fields of a registration token.
+// A nil value in fields resets that field to unlimited or never expiring.
+func UpdateRegistrationToken(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, token string, fields map[string]any) (RegistrationToken, error) {
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return RegistrationToken{}, err
+	}
+	logger.WithFields(logrus.Fields{
+		"event":  "update_registration_token",
+		"fields": fields,
+	}).Debug("Updating registration token")
+	return sendRegistrationToken(ctx, client, "/_synapse/admin/v1/registration_tokens/"+token, "PUT", payload)
+}
+
+// DeleteRegistrationToken deletes a registration token.
+func DeleteRegistrationToken(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, token string) error {
+	logger.WithFields(logrus.Fields{
+		"event": "delete_registration_token",
+	}).Debug("Deleting registration token")
+	_, err := client.Call(ctx, "/_synapse/admin/v1/registration_tokens/"+token, "DELETE", nil, false)
+	return err
+}
+
+func sendRegistrationToken(ctx context.Context, client SynapseClientInterface, path string, method string, payload []byte) (RegistrationToken, error) {
+	var token RegistrationToken
+	output, err := client.Call(ctx, path, method, payload, false)
+	if err != nil {
+		return token, err
+	}
+	if err := json.Unmarshal(output, &token); err != nil {
+		return token, fmt.Errorf("failed to parse registration token: %w", err)
+	}
+	return token, nil
+}
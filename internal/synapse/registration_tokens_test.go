@@ -0,0 +1,104 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListRegistrationTokens(t *testing.T) {
+	valid := true
+	invalid := false
+
+	cases := []struct {
+		name      string
+		valid     *bool
+		responses map[string][]byte
+		errors    map[string]error
+		wantErr   bool
+		wantRows  [][]interface{}
+	}{
+		{
+			name:  "all tokens",
+			valid: nil,
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/registration_tokens": []byte(`{"registration_tokens": [
+					{"token": "abcd", "uses_allowed": 3, "pending": 0, "completed": 1, "expiry_time": null},
+					{"token": "efgh", "uses_allowed": null, "pending": 1, "completed": 0, "expiry_time": 1767225600000}
+				]}`),
+			},
+			wantRows: [][]interface{}{
+				{"abcd", "3", 0, 1, "never"},
+				{"efgh", "unlimited", 1, 0, "2026-01-01T00:00:00Z"},
+			},
+		},
+		{
+			name:      "valid filter",
+			valid:     &valid,
+			responses: map[string][]byte{"/_synapse/admin/v1/registration_tokens?valid=true": []byte(`{"registration_tokens": []}`)},
+			wantRows:  [][]interface{}{},
+		},
+		{
+			name:    "invalid filter request fails",
+			valid:   &invalid,
+			errors:  map[string]error{"/_synapse/admin/v1/registration_tokens?valid=false": assert.AnError},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			tokens, err := ListRegistrationTokens(context.Background(), mock, logrus.New(), tc.valid)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			rows := make([][]interface{}, 0)
+			for _, token := range tokens {
+				rows = append(rows, token.Row())
+			}
+			assert.Equal(t, tc.wantRows, rows)
+		})
+	}
+}
+
+func TestCreateUpdateDeleteRegistrationToken(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{
+		"/_synapse/admin/v1/registration_tokens/new":  []byte(`{"token": "generated", "uses_allowed": 10, "pending": 0, "completed": 0, "expiry_time": null}`),
+		"/_synapse/admin/v1/registration_tokens/abcd": []byte(`{"token": "abcd", "uses_allowed": null, "pending": 0, "completed": 0, "expiry_time": 1767225600000}`),
+	}}
+	uses := 10
+	length := 8
+
+	token, err := CreateRegistrationToken(context.Background(), mock, logrus.New(), NewRegistrationToken{UsesAllowed: &uses, Length: &length})
+	assert.NoError(t, err)
+	assert.Equal(t, "generated", token.Token)
+	assert.JSONEq(t, `{"uses_allowed": 10, "length": 8}`, string(mock.Payloads["/_synapse/admin/v1/registration_tokens/new"]))
+
+	token, err = UpdateRegistrationToken(context.Background(), mock, logrus.New(), "abcd", map[string]any{"uses_allowed": nil, "expiry_time": int64(1767225600000)})
+	assert.NoError(t, err)
+	assert.Nil(t, token.UsesAllowed)
+	assert.JSONEq(t, `{"uses_allowed": null, "expiry_time": 1767225600000}`, string(mock.Payloads["/_synapse/admin/v1/registration_tokens/abcd"]))
+
+	assert.NoError(t, DeleteRegistrationToken(context.Background(), mock, logrus.New(), "abcd"))
+	assert.Error(t, DeleteRegistrationToken(context.Background(), mock, logrus.New(), "missing"))
+}
@@ -0,0 +1,184 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// EventReport is an event reported by a user to the server admins.
+type EventReport struct {
+	ID             int64  `json:"id"`
+	ReceivedTS     int64  `json:"received_ts"`
+	RoomID         string `json:"room_id"`
+	RoomName       string `json:"name"`
+	CanonicalAlias string `json:"canonical_alias"`
+	EventID        string `json:"event_id"`
+	Reporter       string `json:"user_id"`
+	Sender         string `json:"sender"`
+	Reason         string `json:"reason"`
+	Score          *int   `json:"score"`
+}
+
+func (e EventReport) Header() []string {
+	return []string{"ID", "Received", "Room", "Reporter", "Sender", "Reason"}
+}
+
+func (e EventReport) Row() []interface{} {
+	room := e.RoomName
+	if room == "" {
+		room = e.RoomID
+	}
+	return []interface{}{e.ID, time.UnixMilli(e.ReceivedTS).UTC().Format(time.RFC3339), room, e.Reporter, e.Sender, e.Reason}
+}
+
+// EventReportDetails is a report together with the reported event.
+type EventReportDetails struct {
+	EventReport
+	EventJSON json.RawMessage `json:"event_json"`
+}
+
+// ReportField is a single field of a report shown by "reports show".
+type ReportField struct {
+	Field string
+	Value string
+}
+
+func (r ReportField) Header() []string {
+	return []string{"Field", "Value"}
+}
+
+func (r ReportField) Row() []interface{} {
+	return []interface{}{r.Field, r.Value}
+}
+
+// Fields lists the report details in display order.
+func (d EventReportDetails) Fields() []ReportField {
+	score := ""
+	if d.Score != nil {
+		score = strconv.Itoa(*d.Score)
+	}
+	fields := []ReportField{
+		{"ID", strconv.FormatInt(d.ID, 10)},
+		{"Received", time.UnixMilli(d.ReceivedTS).UTC().Format(time.RFC3339)},
+		{"Room ID", d.RoomID},
+		{"Room Name", d.RoomName},
+		{"Room Alias", d.CanonicalAlias},
+		{"Reporter", d.Reporter},
+		{"Reason", d.Reason},
+		{"Score", score},
+		{"Event ID", d.EventID},
+		{"Sender", d.Sender},
+	}
+
+	var event struct {
+		Type    string          `json:"type"`
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(d.EventJSON, &event); err == nil {
+		fields = append(fields, ReportField{"Event Type", event.Type}, ReportField{"Event Content", string(event.Content)})
+	}
+	return fields
+}
+
+// EventReportsQuery filters and paginates ListEventReports.
+type EventReportsQuery struct {
+	From     int
+	Limit    int
+	RoomID   string
+	Reporter string
+	// Direction is "b" for newest first or "f" for oldest first.
+	Direction string
+}
+
+type eventReportsResponse struct {
+	EventReports []EventReport `json:"event_reports"`
+	NextToken    *int          `json:"next_token"`
+	Total        int           `json:"total"`
+}
+
+// ListEventReports returns a page of event reports, the offset of the next
+// page (nil on the last page) and the total number of matching reports.
+func ListEventReports(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, query EventReportsQuery) ([]EventReport, *int, int, error) {
+	params := url.Values{}
+	if query.From > 0 {
+		params.Set("from", strconv.Itoa(query.From))
+	}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.RoomID != "" {
+		params.Set("room_id", query.RoomID)
+	}
+	if query.Reporter != "" {
+		params.Set("user_id", query.Reporter)
+	}
+	if query.Direction != "" {
+		params.Set("dir", query.Direction)
+	}
+	path := "/_synapse/admin/v1/event_reports"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	logger.WithFields(logrus.Fields{
+		"event": "list_event_reports",
+		"path":  path,
+	}).Debug("Listing event reports")
+	var resp eventReportsResponse
+	if err := callJSON(ctx, client, path, &resp); err != nil {
+		return nil, nil, 0, err
+	}
+	return resp.EventReports, resp.NextToken, resp.Total, nil
+}
+
+// GetEventReport returns a report with the reported event. When the report
+// does not carry the room name it is fetched from the room details.
+func GetEventReport(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, id int64) (EventReportDetails, error) {
+	var details EventReportDetails
+	logger.WithFields(logrus.Fields{
+		"event":  "get_event_report",
+		"report": id,
+	}).Debug("Fetching event report")
+	if err := callJSON(ctx, client, "/_synapse/admin/v1/event_reports/"+strconv.FormatInt(id, 10), &details); err != nil {
+		return details, err
+	}
+	if details.RoomName == "" && details.RoomID != "" {
+		room, err := GetRoomDetails(ctx, client, details.RoomID)
+		if err != nil && !IsNotFound(err) {
+			return details, fmt.Errorf("failed to fetch room of report %d: %w", id, err)
+		}
+		details.RoomName = room.Name
+	}
+	return details, nil
+}
+
+// DeleteEventReport deletes a report once it has been handled.
+func DeleteEventReport(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, id int64) error {
+	logger.WithFields(logrus.Fields{
+		"event":  "delete_event_report",
+		"report": id,
+	}).Debug("Deleting event report")
+	_, err := client.Call(ctx, "/_synapse/admin/v1/event_reports/"+strconv.FormatInt(id, 10), "DELETE", nil, false)
+	return err
+}
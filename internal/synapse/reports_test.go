@@ -0,0 +1,113 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListEventReports(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     EventReportsQuery
+		responses map[string][]byte
+		errors    map[string]error
+		wantErr   bool
+		wantIDs   []int64
+		wantNext  *int
+		wantTotal int
+	}{
+		{
+			name:  "filtered first page",
+			query: EventReportsQuery{Limit: 2, RoomID: "!r:matrix.org", Reporter: "@alice:matrix.org", Direction: "b"},
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/event_reports?dir=b&limit=2&room_id=%21r%3Amatrix.org&user_id=%40alice%3Amatrix.org": []byte(`{"event_reports": [{"id": 3, "room_id": "!r:matrix.org"}, {"id": 2, "room_id": "!r:matrix.org"}], "next_token": 2, "total": 3}`),
+			},
+			wantIDs:   []int64{3, 2},
+			wantNext:  intPtr(2),
+			wantTotal: 3,
+		},
+		{
+			name:  "last page",
+			query: EventReportsQuery{From: 2},
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/event_reports?from=2": []byte(`{"event_reports": [{"id": 1}], "total": 3}`),
+			},
+			wantIDs:   []int64{1},
+			wantTotal: 3,
+		},
+		{
+			name:    "request fails",
+			errors:  map[string]error{"/_synapse/admin/v1/event_reports": assert.AnError},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			reports, next, total, err := ListEventReports(context.Background(), mock, logrus.New(), tc.query)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			ids := make([]int64, 0)
+			for _, r := range reports {
+				ids = append(ids, r.ID)
+			}
+			assert.Equal(t, tc.wantIDs, ids)
+			assert.Equal(t, tc.wantNext, next)
+			assert.Equal(t, tc.wantTotal, total)
+		})
+	}
+}
+
+func TestGetEventReport(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{
+		"/_synapse/admin/v1/event_reports/7":     []byte(`{"id": 7, "room_id": "!r:matrix.org", "event_id": "$e", "user_id": "@alice:matrix.org", "sender": "@spam:matrix.org", "reason": "spam", "event_json": {"type": "m.room.message", "content": {"body": "buy now", "msgtype": "m.text"}}}`),
+		"/_synapse/admin/v1/rooms/!r:matrix.org": []byte(`{"room_id": "!r:matrix.org", "name": "General"}`),
+	}}
+
+	details, err := GetEventReport(context.Background(), mock, logrus.New(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, "General", details.RoomName)
+
+	fields := make(map[string]string)
+	for _, f := range details.Fields() {
+		fields[f.Field] = f.Value
+	}
+	assert.Equal(t, "m.room.message", fields["Event Type"])
+	assert.JSONEq(t, `{"body": "buy now", "msgtype": "m.text"}`, fields["Event Content"])
+	assert.Equal(t, "@spam:matrix.org", fields["Sender"])
+
+	_, err = GetEventReport(context.Background(), mock, logrus.New(), 8)
+	assert.Error(t, err)
+}
+
+func TestDeleteEventReport(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{"/_synapse/admin/v1/event_reports/7": []byte(`{}`)}}
+	assert.NoError(t, DeleteEventReport(context.Background(), mock, logrus.New(), 7))
+	assert.Error(t, DeleteEventReport(context.Background(), mock, logrus.New(), 8))
+}
+
+func intPtr(i int) *int {
+	return &i
+}
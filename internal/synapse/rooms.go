@@ -18,6 +18,9 @@ package synapse
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -33,11 +36,38 @@ type StateResponse struct {
 }
 
 type Space struct {
-	ID         string
-	Name       string
-	Members    int
-	ChildCount int
-	ChildRooms []string
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Members    int          `json:"members"`
+	ChildCount int          `json:"child_count"`
+	ChildRooms []string     `json:"child_rooms"`
+	Children   []SpaceChild `json:"children"`
+}
+
+// RoomState summarizes the state events of a room that matter for space hierarchies.
+type RoomState struct {
+	RoomID   string
+	IsSpace  bool
+	JoinRule string
+	// Allow holds the room IDs granting access when the join rule is restricted.
+	Allow    []string
+	Children []SpaceChild
+	// InvalidChildren holds the m.space.child events whose content could not be used.
+	InvalidChildren []InvalidSpaceChild
+	Parents         []string
+}
+
+// InvalidSpaceChild is a m.space.child event that was skipped because of its content.
+type InvalidSpaceChild struct {
+	RoomID string
+	Reason string
+}
+
+// SpaceChild describes a room referenced by a m.space.child state event.
+type SpaceChild struct {
+	RoomID    string   `json:"room_id"`
+	Via       []string `json:"via"`
+	Suggested bool     `json:"suggested"`
 }
 
 func (s Space) Header() []string {
@@ -51,12 +81,46 @@ func (s Space) Row() []interface{} {
 const maxConcurrentRequests = 10
 const maxConcurrentRequestsTimeout = 5 * time.Minute
 
-func GetSpaces(client SynapseClientInterface, logger *logrus.Logger) ([]Space, error) {
+// SpacesQuery selects which public room directory GetSpaces reads from.
+type SpacesQuery struct {
+	// Server is the remote server whose directory is queried. Empty means the local server.
+	Server string
+	// ThirdPartyInstanceID selects the directory of a bridged network.
+	ThirdPartyInstanceID string
+}
+
+type publicRoomsFilter struct {
+	RoomTypes []string `json:"room_types"`
+}
+
+type publicRoomsRequest struct {
+	Limit                int               `json:"limit"`
+	Filter               publicRoomsFilter `json:"filter"`
+	ThirdPartyInstanceID string            `json:"third_party_instance_id,omitempty"`
+}
+
+func GetSpaces(client SynapseClientInterface, logger *logrus.Logger, query SpacesQuery) ([]Space, error) {
 	var spaces []Space
 	ctx, cancel := context.WithTimeout(context.Background(), maxConcurrentRequestsTimeout) // Set a timeout for the entire operation to avoid hanging indefinitely in case of issues with the server
 	defer cancel()
-	payload := []byte(`{"limit": 200, "filter": {"room_types": ["m.space"]}}`)
-	output, err := client.Call(ctx, "/_matrix/client/v3/publicRooms", "POST", payload, false)
+	payload, err := json.Marshal(publicRoomsRequest{
+		Limit:                200,
+		Filter:               publicRoomsFilter{RoomTypes: []string{"m.space"}},
+		ThirdPartyInstanceID: query.ThirdPartyInstanceID,
+	})
+	if err != nil {
+		return spaces, err
+	}
+	path := "/_matrix/client/v3/publicRooms"
+	if query.Server != "" {
+		path += "?server=" + url.QueryEscape(query.Server)
+	}
+	logger.WithFields(logrus.Fields{
+		"event":                "fetching_public_spaces",
+		"server":               query.Server,
+		"third_party_instance": query.ThirdPartyInstanceID,
+	}).Debug("Fetching public spaces")
+	output, err := client.Call(ctx, path, "POST", payload, false)
 	if err != nil {
 		return spaces, err
 	}
@@ -94,29 +158,37 @@ func GetSpaces(client SynapseClientInterface, logger *logrus.Logger) ([]Space, e
 				"event": "fetching_space_details",
 				"space": spaces[i].ID,
 			}).Debug("Fetching details for space")
-			output, err := client.Call(ctx, "/_synapse/admin/v1/rooms/"+spaces[i].ID+"/state", "GET", nil, false)
+			state, err := GetRoomState(ctx, client, spaces[i].ID)
+			if IsNotFound(err) {
+				// Spaces listed in a remote directory are unknown locally until someone joins them
+				logger.WithFields(logrus.Fields{
+					"event": "space_not_known_locally",
+					"space": spaces[i].ID,
+				}).Debug("Space state is not available on this server")
+				return nil
+			}
 			if err != nil {
 				return err
 			}
 
-			var resp StateResponse
-			if err := json.Unmarshal(output, &resp); err != nil {
-				return err
+			for _, invalid := range state.InvalidChildren {
+				logger.WithFields(logrus.Fields{
+					"event":  "invalid_space_child",
+					"space":  spaces[i].ID,
+					"room":   invalid.RoomID,
+					"reason": invalid.Reason,
+				}).Warn("Skipping space child with invalid content")
 			}
 
-			childCount := 0
-			childRooms := make([]string, 0)
-
-			for _, event := range resp.State {
-				if event.Type.String() == "m.space.child" && event.StateKey != nil {
-					childCount++
-					childRooms = append(childRooms, *event.StateKey)
-				}
+			childRooms := make([]string, 0, len(state.Children))
+			for _, child := range state.Children {
+				childRooms = append(childRooms, child.RoomID)
 			}
 
 			mu.Lock()
-			spaces[i].ChildCount += childCount
+			spaces[i].ChildCount += len(state.Children)
 			spaces[i].ChildRooms = append(spaces[i].ChildRooms, childRooms...)
+			spaces[i].Children = append(spaces[i].Children, state.Children...)
 			mu.Unlock()
 
 			return nil
@@ -149,7 +221,132 @@ func parseSpaces(data []byte) ([]Space, error) {
 			Members:    room.NumJoinedMembers,
 			ChildCount: 0,
 			ChildRooms: []string{},
+			Children:   []SpaceChild{},
 		})
 	}
 	return spaces, nil
 }
+
+// GetRoomState fetches the full state of a room through the admin API and
+// extracts the space hierarchy related events from it.
+func GetRoomState(ctx context.Context, client SynapseClientInterface, roomID string) (RoomState, error) {
+	state := RoomState{RoomID: roomID, Children: []SpaceChild{}}
+	output, err := client.Call(ctx, "/_synapse/admin/v1/rooms/"+roomID+"/state", "GET", nil, false)
+	if err != nil {
+		return state, err
+	}
+
+	var resp StateResponse
+	if err := json.Unmarshal(output, &resp); err != nil {
+		return state, err
+	}
+
+	for _, event := range resp.State {
+		if event.StateKey == nil {
+			continue
+		}
+		switch event.Type.String() {
+		case "m.space.child":
+			child, ok, err := parseSpaceChild(&event)
+			if !ok {
+				continue
+			}
+			if err != nil {
+				// One bad event must not hide the rest of the hierarchy
+				state.InvalidChildren = append(state.InvalidChildren, InvalidSpaceChild{RoomID: *event.StateKey, Reason: err.Error()})
+				continue
+			}
+			state.Children = append(state.Children, child)
+		case "m.space.parent":
+			// A parent with empty content or without via has been removed
+			if _, err := parseVia(event.Content.Raw); err != nil {
+				continue
+			}
+			state.Parents = append(state.Parents, *event.StateKey)
+		case "m.room.create":
+			if roomType, ok := event.Content.Raw["type"].(string); ok && roomType == "m.space" {
+				state.IsSpace = true
+			}
+		case "m.room.join_rules":
+			if len(event.Content.VeryRaw) == 0 {
+				continue
+			}
+			if err := event.Content.ParseRaw(event.Type); err != nil {
+				return state, fmt.Errorf("failed to parse m.room.join_rules content for %s: %w", roomID, err)
+			}
+			content := event.Content.AsJoinRules()
+			state.JoinRule = string(content.JoinRule)
+			for _, allow := range content.Allow {
+				state.Allow = append(state.Allow, allow.RoomID.String())
+			}
+		}
+	}
+	return state, nil
+}
+
+// parseSpaceChild extracts the via servers and suggested flag from a
+// m.space.child event. A child with empty content has been removed from the
+// space and is reported with ok set to false. Content without a usable via
+// list is returned as an error.
+func parseSpaceChild(event *mauevent.Event) (child SpaceChild, ok bool, err error) {
+	child = SpaceChild{RoomID: *event.StateKey}
+	if len(event.Content.Raw) == 0 {
+		return child, false, nil
+	}
+	child.Via, err = parseVia(event.Content.Raw)
+	if err != nil {
+		return child, true, fmt.Errorf("m.space.child for %s %w", child.RoomID, err)
+	}
+	child.Suggested, _ = event.Content.Raw["suggested"].(bool)
+	return child, true, nil
+}
+
+// parseVia returns the via servers of a m.space.child or m.space.parent
+// content, which must be a non-empty list of server names.
+func parseVia(content map[string]interface{}) ([]string, error) {
+	raw, ok := content["via"]
+	if !ok {
+		return nil, errors.New("has no via servers")
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("has a malformed via: %v", raw)
+	}
+	if len(list) == 0 {
+		return nil, errors.New("has an empty via list")
+	}
+	via := make([]string, 0, len(list))
+	for _, item := range list {
+		server, ok := item.(string)
+		if !ok || server == "" {
+			return nil, fmt.Errorf("has a malformed via server: %v", item)
+		}
+		via = append(via, server)
+	}
+	return via, nil
+}
+
+// RoomDetails holds the subset of the admin room details used by syncli.
+type RoomDetails struct {
+	RoomID           string `json:"room_id"`
+	Name             string `json:"name"`
+	CanonicalAlias   string `json:"canonical_alias"`
+	JoinedMembers    int    `json:"joined_members"`
+	JoinRules        string `json:"join_rules"`
+	RoomType         string `json:"room_type"`
+	Public           bool   `json:"public"`
+	JoinedLocalUsers int    `json:"joined_local_members"`
+}
+
+// GetRoomDetails retrieves the admin view of a single room.
+func GetRoomDetails(ctx context.Context, client SynapseClientInterface, roomID string) (RoomDetails, error) {
+	var details RoomDetails
+	output, err := client.Call(ctx, "/_synapse/admin/v1/rooms/"+roomID, "GET", nil, false)
+	if err != nil {
+		return details, err
+	}
+	if err := json.Unmarshal(output, &details); err != nil {
+		return details, fmt.Errorf("failed to parse details of room %s: %w", roomID, err)
+	}
+	return details, nil
+}
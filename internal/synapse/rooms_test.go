@@ -18,6 +18,8 @@ package synapse
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -29,9 +31,19 @@ type MockClient struct {
 	// We use a map to store "path -> response" so we can handle multiple calls
 	Responses map[string][]byte
 	Errors    map[string]error
+	// Payloads records the last payload sent to each path
+	Payloads map[string][]byte
+
+	mu sync.Mutex
 }
 
 func (m *MockClient) Call(ctx context.Context, path string, method string, payload []byte, retry bool) ([]byte, error) {
+	m.mu.Lock()
+	if m.Payloads == nil {
+		m.Payloads = make(map[string][]byte)
+	}
+	m.Payloads[path] = payload
+	m.mu.Unlock()
 	if err, ok := m.Errors[path]; ok && err != nil {
 		return nil, err
 	}
@@ -55,7 +67,7 @@ func TestGetSpaces(t *testing.T) {
 			name: "single space, two children",
 			responses: map[string][]byte{
 				"/_matrix/client/v3/publicRooms":                     []byte(`{"chunk": [{"room_id": "!room1:xentonix.net", "name": "Ubuntu Community", "num_joined_members": 1500}]}`),
-				"/_synapse/admin/v1/rooms/!room1:xentonix.net/state": []byte(`{"state": [{"type": "m.space.child", "state_key": "!child1:matrix.org"}, {"type": "m.space.child", "state_key": "!child2:matrix.org"}]}`),
+				"/_synapse/admin/v1/rooms/!room1:xentonix.net/state": []byte(`{"state": [{"type": "m.space.child", "state_key": "!child1:matrix.org", "content": {"via": ["matrix.org"]}}, {"type": "m.space.child", "state_key": "!child2:matrix.org", "content": {"via": ["matrix.org"]}}]}`),
 			},
 			wantErr:   false,
 			wantLen:   1,
@@ -79,6 +91,26 @@ func TestGetSpaces(t *testing.T) {
 			wantErr: false,
 			wantLen: 0,
 		},
+		{
+			name: "malformed child content is skipped",
+			responses: map[string][]byte{
+				"/_matrix/client/v3/publicRooms":                   []byte(`{"chunk": [{"room_id": "!room3:matrix.org", "name": "Mixed", "num_joined_members": 3}]}`),
+				"/_synapse/admin/v1/rooms/!room3:matrix.org/state": []byte(`{"state": [{"type": "m.space.child", "state_key": "!ok:matrix.org", "content": {"via": ["matrix.org"]}}, {"type": "m.space.child", "state_key": "!bad:matrix.org", "content": {"via": "matrix.org"}}]}`),
+			},
+			wantLen:   1,
+			wantName:  "Mixed",
+			wantChild: 1,
+		},
+		{
+			name: "removed child is not counted",
+			responses: map[string][]byte{
+				"/_matrix/client/v3/publicRooms":                   []byte(`{"chunk": [{"room_id": "!room4:matrix.org", "name": "Pruned", "num_joined_members": 2}]}`),
+				"/_synapse/admin/v1/rooms/!room4:matrix.org/state": []byte(`{"state": [{"type": "m.space.child", "state_key": "!ok:matrix.org", "content": {"via": ["matrix.org"]}}, {"type": "m.space.child", "state_key": "!gone:matrix.org", "content": {}}]}`),
+			},
+			wantLen:   1,
+			wantName:  "Pruned",
+			wantChild: 1,
+		},
 		{
 			name: "malformed state json",
 			responses: map[string][]byte{
@@ -94,7 +126,7 @@ func TestGetSpaces(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
 			logger := logrus.New()
-			spaces, err := GetSpaces(mock, logger)
+			spaces, err := GetSpaces(mock, logger, SpacesQuery{})
 			if tc.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -108,3 +140,49 @@ func TestGetSpaces(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSpacesQuery(t *testing.T) {
+	cases := []struct {
+		name        string
+		query       SpacesQuery
+		path        string
+		wantPayload string
+	}{
+		{
+			name:        "local directory",
+			query:       SpacesQuery{},
+			path:        "/_matrix/client/v3/publicRooms",
+			wantPayload: `{"limit": 200, "filter": {"room_types": ["m.space"]}}`,
+		},
+		{
+			name:        "remote server",
+			query:       SpacesQuery{Server: "matrix.org"},
+			path:        "/_matrix/client/v3/publicRooms?server=matrix.org",
+			wantPayload: `{"limit": 200, "filter": {"room_types": ["m.space"]}}`,
+		},
+		{
+			name:        "bridged network",
+			query:       SpacesQuery{Server: "matrix.org", ThirdPartyInstanceID: "irc-libera"},
+			path:        "/_matrix/client/v3/publicRooms?server=matrix.org",
+			wantPayload: `{"limit": 200, "filter": {"room_types": ["m.space"]}, "third_party_instance_id": "irc-libera"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{
+				Responses: map[string][]byte{
+					tc.path: []byte(`{"chunk": [{"room_id": "!remote:matrix.org", "name": "Remote", "num_joined_members": 5}]}`),
+				},
+				Errors: map[string]error{
+					"/_synapse/admin/v1/rooms/!remote:matrix.org/state": &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+				},
+			}
+			spaces, err := GetSpaces(mock, logrus.New(), tc.query)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(spaces))
+			assert.Equal(t, 0, spaces[0].ChildCount)
+			assert.JSONEq(t, tc.wantPayload, string(mock.Payloads[tc.path]))
+		})
+	}
+}
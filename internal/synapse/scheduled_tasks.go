@@ -0,0 +1,100 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ScheduledTask is a task queued or run by the Synapse task scheduler, such
+// as a room purge or a user redaction.
+type ScheduledTask struct {
+	ID          string `json:"id"`
+	Action      string `json:"action"`
+	Status      string `json:"status"`
+	TimestampMS int64  `json:"timestamp_ms"`
+	ResourceID  string `json:"resource_id"`
+	Error       string `json:"error"`
+}
+
+func (s ScheduledTask) Header() []string {
+	return []string{"ID", "Action", "Status", "Updated", "Resource ID", "Error"}
+}
+
+func (s ScheduledTask) Row() []interface{} {
+	return []interface{}{s.ID, s.Action, s.Status, time.UnixMilli(s.TimestampMS).UTC().Format(time.RFC3339), s.ResourceID, s.Error}
+}
+
+// ScheduledTasksQuery filters the scheduled tasks. Zero values do not filter.
+type ScheduledTasksQuery struct {
+	ActionName string
+	ResourceID string
+	// Status is one of scheduled, active, complete or failed.
+	Status string
+	// FromTS drops tasks last updated before this time, in milliseconds.
+	// The server cannot filter on it, so it is applied to the response.
+	FromTS int64
+	// UntilTS drops tasks last updated after this time, in milliseconds.
+	UntilTS int64
+}
+
+type scheduledTasksResponse struct {
+	ScheduledTasks []ScheduledTask `json:"scheduled_tasks"`
+}
+
+// ListScheduledTasks returns the scheduled tasks matching the query.
+func ListScheduledTasks(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, query ScheduledTasksQuery) ([]ScheduledTask, error) {
+	params := url.Values{}
+	if query.ActionName != "" {
+		params.Set("action_name", query.ActionName)
+	}
+	if query.ResourceID != "" {
+		params.Set("resource_id", query.ResourceID)
+	}
+	if query.Status != "" {
+		params.Set("job_status", query.Status)
+	}
+	if query.UntilTS > 0 {
+		params.Set("max_timestamp", strconv.FormatInt(query.UntilTS, 10))
+	}
+	path := "/_synapse/admin/v1/scheduled_tasks"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	logger.WithFields(logrus.Fields{
+		"event":  "list_scheduled_tasks",
+		"params": params.Encode(),
+	}).Debug("Listing scheduled tasks")
+	var resp scheduledTasksResponse
+	if err := callJSON(ctx, client, path, &resp); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]ScheduledTask, 0, len(resp.ScheduledTasks))
+	for _, task := range resp.ScheduledTasks {
+		if query.FromTS > 0 && task.TimestampMS < query.FromTS {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
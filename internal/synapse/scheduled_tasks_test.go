@@ -0,0 +1,83 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListScheduledTasks(t *testing.T) {
+	tasks := []byte(`{"scheduled_tasks": [
+		{"id": "a", "action": "shutdown_and_purge_room", "status": "complete", "timestamp_ms": 1000, "resource_id": "!a:matrix.org"},
+		{"id": "b", "action": "redact_all_events", "status": "failed", "timestamp_ms": 5000, "resource_id": "@spam:matrix.org", "error": "boom"}
+	]}`)
+
+	cases := []struct {
+		name      string
+		query     ScheduledTasksQuery
+		responses map[string][]byte
+		errors    map[string]error
+		wantErr   bool
+		wantIDs   []string
+	}{
+		{
+			name:      "no filters",
+			responses: map[string][]byte{"/_synapse/admin/v1/scheduled_tasks": tasks},
+			wantIDs:   []string{"a", "b"},
+		},
+		{
+			name:      "server side filters",
+			query:     ScheduledTasksQuery{ActionName: "redact_all_events", ResourceID: "@spam:matrix.org", Status: "failed", UntilTS: 6000},
+			responses: map[string][]byte{"/_synapse/admin/v1/scheduled_tasks?action_name=redact_all_events&job_status=failed&max_timestamp=6000&resource_id=%40spam%3Amatrix.org": tasks},
+			wantIDs:   []string{"a", "b"},
+		},
+		{
+			name:      "from is applied locally",
+			query:     ScheduledTasksQuery{FromTS: 2000},
+			responses: map[string][]byte{"/_synapse/admin/v1/scheduled_tasks": tasks},
+			wantIDs:   []string{"b"},
+		},
+		{
+			name:    "request fails",
+			errors:  map[string]error{"/_synapse/admin/v1/scheduled_tasks": assert.AnError},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			got, err := ListScheduledTasks(context.Background(), mock, logrus.New(), tc.query)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			ids := make([]string, 0, len(got))
+			for _, task := range got {
+				ids = append(ids, task.ID)
+			}
+			assert.Equal(t, tc.wantIDs, ids)
+		})
+	}
+
+	row := ScheduledTask{ID: "b", Action: "redact_all_events", Status: "failed", TimestampMS: 0, ResourceID: "@spam:matrix.org", Error: "boom"}.Row()
+	assert.Equal(t, []interface{}{"b", "redact_all_events", "failed", "1970-01-01T00:00:00Z", "@spam:matrix.org", "boom"}, row)
+}
@@ -0,0 +1,196 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/amandahla/syncli/internal"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// UserMediaStats is the media usage of a single local user.
+type UserMediaStats struct {
+	UserID      string `json:"user_id"`
+	DisplayName string `json:"displayname"`
+	MediaCount  int    `json:"media_count"`
+	MediaLength int64  `json:"media_length"`
+}
+
+func (u UserMediaStats) Header() []string {
+	return []string{"User", "Display Name", "Media Count", "Media Size"}
+}
+
+func (u UserMediaStats) Row() []interface{} {
+	return []interface{}{u.UserID, u.DisplayName, u.MediaCount, internal.HumanBytes(u.MediaLength)}
+}
+
+// MediaStatsQuery filters the user media statistics. Timestamps are in
+// milliseconds since the epoch and zero values are left out of the request.
+type MediaStatsQuery struct {
+	FromTS  int64
+	UntilTS int64
+	// OrderBy is one of user_id, displayname, media_length or media_count.
+	OrderBy string
+	// Direction is "f" for ascending or "b" for descending order.
+	Direction  string
+	SearchTerm string
+	// Limit returns only the first Limit users. Zero reads every page.
+	Limit int
+}
+
+type userMediaStatsResponse struct {
+	Users     []UserMediaStats `json:"users"`
+	NextToken json.Number      `json:"next_token"`
+	Total     int              `json:"total"`
+}
+
+const statisticsPageSize = 100
+
+// GetUserMediaStatistics returns the media usage of every local user matching
+// the query, following pagination until all pages are read.
+func GetUserMediaStatistics(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, query MediaStatsQuery) ([]UserMediaStats, error) {
+	users := make([]UserMediaStats, 0)
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(statisticsPageSize))
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.OrderBy != "" {
+		params.Set("order_by", query.OrderBy)
+	}
+	if query.Direction != "" {
+		params.Set("dir", query.Direction)
+	}
+	if query.SearchTerm != "" {
+		params.Set("search_term", query.SearchTerm)
+	}
+	if query.FromTS > 0 {
+		params.Set("from_ts", strconv.FormatInt(query.FromTS, 10))
+	}
+	if query.UntilTS > 0 {
+		params.Set("until_ts", strconv.FormatInt(query.UntilTS, 10))
+	}
+
+	for {
+		logger.WithFields(logrus.Fields{
+			"event":  "fetching_user_media_statistics",
+			"params": params.Encode(),
+		}).Debug("Fetching user media statistics")
+		output, err := client.Call(ctx, "/_synapse/admin/v1/statistics/users/media?"+params.Encode(), "GET", nil, false)
+		if err != nil {
+			return nil, err
+		}
+		var resp userMediaStatsResponse
+		if err := json.Unmarshal(output, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse user media statistics: %w", err)
+		}
+		users = append(users, resp.Users...)
+		if resp.NextToken == "" || (query.Limit > 0 && len(users) >= query.Limit) {
+			return users, nil
+		}
+		params.Set("from", resp.NextToken.String())
+	}
+}
+
+// RoomDatabaseSize is the estimated database size of a room.
+type RoomDatabaseSize struct {
+	RoomID         string `json:"room_id"`
+	EstimatedSize  int64  `json:"estimated_size"`
+	Name           string `json:"-"`
+	CanonicalAlias string `json:"-"`
+	JoinedMembers  int    `json:"-"`
+}
+
+func (r RoomDatabaseSize) Header() []string {
+	return []string{"Room ID", "Name", "Alias", "Members", "Estimated Size"}
+}
+
+func (r RoomDatabaseSize) Row() []interface{} {
+	return []interface{}{r.RoomID, r.Name, r.CanonicalAlias, r.JoinedMembers, internal.HumanBytes(r.EstimatedSize)}
+}
+
+type roomDatabaseStatsResponse struct {
+	Rooms []RoomDatabaseSize `json:"rooms"`
+}
+
+// GetRoomDatabaseStatistics returns the rooms taking the most database space,
+// largest first, with their names and member counts. When top is positive
+// only the top largest rooms are returned and only their details are fetched.
+func GetRoomDatabaseStatistics(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, top int) ([]RoomDatabaseSize, error) {
+	var resp roomDatabaseStatsResponse
+	if err := callJSON(ctx, client, "/_synapse/admin/v1/statistics/database/rooms", &resp); err != nil {
+		return nil, err
+	}
+	rooms := resp.Rooms
+	slices.SortStableFunc(rooms, func(a, b RoomDatabaseSize) int {
+		return cmp.Compare(b.EstimatedSize, a.EstimatedSize)
+	})
+	if top > 0 && len(rooms) > top {
+		rooms = rooms[:top]
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	sem := make(chan struct{}, maxConcurrentRequests)
+
+	logger.WithFields(logrus.Fields{
+		"event": "fetching_room_database_details",
+		"count": len(rooms),
+	}).Debug("Fetching details for the largest rooms")
+
+	for i := range rooms {
+		g.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-gctx.Done():
+				return gctx.Err()
+			}
+
+			defer func() { <-sem }()
+
+			details, err := GetRoomDetails(gctx, client, rooms[i].RoomID)
+			if IsNotFound(err) {
+				// Statistics may still list rooms that were purged since
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			// Each goroutine writes to its own element, so no lock is needed
+			rooms[i].Name = details.Name
+			rooms[i].CanonicalAlias = details.CanonicalAlias
+			rooms[i].JoinedMembers = details.JoinedMembers
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"event": "room_database_statistics",
+		"rooms": len(rooms),
+	}).Debug("Fetched room database statistics")
+	return rooms, nil
+}
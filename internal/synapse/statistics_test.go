@@ -0,0 +1,156 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUserMediaStatistics(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     MediaStatsQuery
+		responses map[string][]byte
+		errors    map[string]error
+		wantErr   bool
+		wantUsers []string
+	}{
+		{
+			name:  "two pages",
+			query: MediaStatsQuery{UntilTS: 1700000000000},
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/statistics/users/media?limit=100&until_ts=1700000000000":        []byte(`{"users": [{"user_id": "@a:matrix.org", "media_count": 2, "media_length": 100}], "next_token": 1, "total": 2}`),
+				"/_synapse/admin/v1/statistics/users/media?from=1&limit=100&until_ts=1700000000000": []byte(`{"users": [{"user_id": "@b:matrix.org", "media_count": 1, "media_length": 50}], "total": 2}`),
+			},
+			wantUsers: []string{"@a:matrix.org", "@b:matrix.org"},
+		},
+		{
+			name:  "time window",
+			query: MediaStatsQuery{FromTS: 1600000000000, UntilTS: 1700000000000},
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/statistics/users/media?from_ts=1600000000000&limit=100&until_ts=1700000000000": []byte(`{"users": [], "total": 0}`),
+			},
+			wantUsers: []string{},
+		},
+		{
+			name:    "request fails",
+			errors:  map[string]error{"/_synapse/admin/v1/statistics/users/media?limit=100": assert.AnError},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			users, err := GetUserMediaStatistics(context.Background(), mock, logrus.New(), tc.query)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			got := make([]string, 0)
+			for _, u := range users {
+				got = append(got, u.UserID)
+			}
+			assert.Equal(t, tc.wantUsers, got)
+		})
+	}
+}
+
+func TestGetUserMediaStatisticsTopN(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{
+		"/_synapse/admin/v1/statistics/users/media?dir=b&limit=2&order_by=media_length&search_term=ali": []byte(`{"users": [{"user_id": "@alice:matrix.org", "media_count": 9, "media_length": 3145728}, {"user_id": "@alina:matrix.org", "media_count": 1, "media_length": 10}], "next_token": 2, "total": 3}`),
+	}}
+	users, err := GetUserMediaStatistics(context.Background(), mock, logrus.New(), MediaStatsQuery{OrderBy: "media_length", Direction: "b", SearchTerm: "ali", Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(users))
+	assert.Equal(t, []interface{}{"@alice:matrix.org", "", 9, "3.0 MB"}, users[0].Row())
+}
+
+func TestGetRoomDatabaseStatistics(t *testing.T) {
+	stats := []byte(`{"rooms": [
+		{"room_id": "!small:matrix.org", "estimated_size": 1024},
+		{"room_id": "!big:matrix.org", "estimated_size": 5242880},
+		{"room_id": "!mid:matrix.org", "estimated_size": 2048}
+	]}`)
+
+	cases := []struct {
+		name      string
+		top       int
+		responses map[string][]byte
+		errors    map[string]error
+		wantErr   bool
+		wantRows  [][]interface{}
+	}{
+		{
+			name: "top two joined with names",
+			top:  2,
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/statistics/database/rooms": stats,
+				"/_synapse/admin/v1/rooms/!big:matrix.org":     []byte(`{"room_id": "!big:matrix.org", "name": "Big", "canonical_alias": "#big:matrix.org", "joined_members": 900}`),
+				"/_synapse/admin/v1/rooms/!mid:matrix.org":     []byte(`{"room_id": "!mid:matrix.org", "name": "Mid", "joined_members": 4}`),
+			},
+			wantRows: [][]interface{}{
+				{"!big:matrix.org", "Big", "#big:matrix.org", 900, "5.0 MB"},
+				{"!mid:matrix.org", "Mid", "", 4, "2.0 KB"},
+			},
+		},
+		{
+			name: "purged room keeps its size",
+			top:  1,
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/statistics/database/rooms": stats,
+			},
+			errors: map[string]error{
+				"/_synapse/admin/v1/rooms/!big:matrix.org": &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+			},
+			wantRows: [][]interface{}{
+				{"!big:matrix.org", "", "", 0, "5.0 MB"},
+			},
+		},
+		{
+			name: "room details fail",
+			top:  1,
+			responses: map[string][]byte{
+				"/_synapse/admin/v1/statistics/database/rooms": stats,
+			},
+			errors:  map[string]error{"/_synapse/admin/v1/rooms/!big:matrix.org": assert.AnError},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			rooms, err := GetRoomDatabaseStatistics(context.Background(), mock, logrus.New(), tc.top)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			rows := make([][]interface{}, 0)
+			for _, r := range rooms {
+				rows = append(rows, r.Row())
+			}
+			assert.Equal(t, tc.wantRows, rows)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// StatusCheck is the outcome of a single check run by CheckStatus.
+type StatusCheck struct {
+	Name   string
+	OK     bool
+	Detail string
+}
+
+func (s StatusCheck) Header() []string {
+	return []string{"Check", "Status", "Detail"}
+}
+
+func (s StatusCheck) Row() []interface{} {
+	status := "failed"
+	if s.OK {
+		status = "ok"
+	}
+	return []interface{}{s.Name, status, s.Detail}
+}
+
+type serverVersionResponse struct {
+	ServerVersion string `json:"server_version"`
+}
+
+type clientVersionsResponse struct {
+	Versions []string `json:"versions"`
+}
+
+type whoamiResponse struct {
+	UserID   string `json:"user_id"`
+	DeviceID string `json:"device_id"`
+}
+
+type userAdminResponse struct {
+	Admin bool `json:"admin"`
+}
+
+// CheckStatus verifies that the homeserver is reachable and healthy and that
+// the configured access token belongs to a server admin. Failed checks are
+// reported in the result rather than as an error.
+func CheckStatus(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger) []StatusCheck {
+	checks := make([]StatusCheck, 0, 5)
+
+	check := func(name string, run func() (string, error)) bool {
+		detail, err := run()
+		if err != nil {
+			detail = diagnose(err)
+		}
+		logger.WithFields(logrus.Fields{
+			"event":  "status_check",
+			"check":  name,
+			"ok":     err == nil,
+			"detail": detail,
+		}).Debug("Status check finished")
+		checks = append(checks, StatusCheck{Name: name, OK: err == nil, Detail: detail})
+		return err == nil
+	}
+
+	check("health", func() (string, error) {
+		output, err := client.Call(ctx, "/health", "GET", nil, false)
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(string(output)) != "OK" {
+			return "", fmt.Errorf("unexpected health response: %q", strings.TrimSpace(string(output)))
+		}
+		return "OK", nil
+	})
+
+	check("server_version", func() (string, error) {
+		var resp serverVersionResponse
+		if err := callJSON(ctx, client, "/_synapse/admin/v1/server_version", &resp); err != nil {
+			return "", err
+		}
+		return "Synapse " + resp.ServerVersion, nil
+	})
+
+	check("client_versions", func() (string, error) {
+		var resp clientVersionsResponse
+		if err := callJSON(ctx, client, "/_matrix/client/versions", &resp); err != nil {
+			return "", err
+		}
+		if len(resp.Versions) == 0 {
+			return "", errors.New("server advertises no client-server API versions")
+		}
+		return "latest " + resp.Versions[len(resp.Versions)-1], nil
+	})
+
+	var whoami whoamiResponse
+	if !check("whoami", func() (string, error) {
+		if err := callJSON(ctx, client, "/_matrix/client/v3/account/whoami", &whoami); err != nil {
+			return "", err
+		}
+		return whoami.UserID, nil
+	}) {
+		checks = append(checks, StatusCheck{Name: "admin", Detail: "skipped: token could not be verified"})
+		return checks
+	}
+
+	check("admin", func() (string, error) {
+		var resp userAdminResponse
+		if err := callJSON(ctx, client, "/_synapse/admin/v1/users/"+whoami.UserID+"/admin", &resp); err != nil {
+			return "", err
+		}
+		if !resp.Admin {
+			return "", fmt.Errorf("%s is not a server admin", whoami.UserID)
+		}
+		return whoami.UserID + " is a server admin", nil
+	})
+
+	return checks
+}
+
+// callJSON performs a GET request and decodes the JSON response into v.
+func callJSON(ctx context.Context, client SynapseClientInterface, path string, v any) error {
+	output, err := client.Call(ctx, path, "GET", nil, false)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(output, v); err != nil {
+		return fmt.Errorf("failed to parse response from %s: %w", path, err)
+	}
+	return nil
+}
+
+// diagnose turns a failed request into an actionable message.
+func diagnose(err error) string {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusUnauthorized:
+			return "access token is invalid or expired"
+		case http.StatusForbidden:
+			return "access token does not belong to a server admin"
+		case http.StatusNotFound:
+			return "endpoint not found, check base_url points to the homeserver"
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStatus(t *testing.T) {
+	healthy := map[string][]byte{
+		"/health":                                          []byte("OK"),
+		"/_synapse/admin/v1/server_version":                []byte(`{"server_version": "1.120.0"}`),
+		"/_matrix/client/versions":                         []byte(`{"versions": ["v1.10", "v1.11"]}`),
+		"/_matrix/client/v3/account/whoami":                []byte(`{"user_id": "@admin:matrix.org"}`),
+		"/_synapse/admin/v1/users/@admin:matrix.org/admin": []byte(`{"admin": true}`),
+	}
+
+	cases := []struct {
+		name       string
+		responses  map[string][]byte
+		errors     map[string]error
+		wantOK     map[string]bool
+		wantDetail map[string]string
+	}{
+		{
+			name:      "all checks pass",
+			responses: healthy,
+			wantOK:    map[string]bool{"health": true, "server_version": true, "client_versions": true, "whoami": true, "admin": true},
+			wantDetail: map[string]string{
+				"server_version":  "Synapse 1.120.0",
+				"client_versions": "latest v1.11",
+			},
+		},
+		{
+			name: "token is not an admin",
+			responses: map[string][]byte{
+				"/health":                           []byte("OK"),
+				"/_synapse/admin/v1/server_version": []byte(`{"server_version": "1.120.0"}`),
+				"/_matrix/client/versions":          []byte(`{"versions": ["v1.11"]}`),
+				"/_matrix/client/v3/account/whoami": []byte(`{"user_id": "@bob:matrix.org"}`),
+			},
+			errors: map[string]error{
+				"/_synapse/admin/v1/users/@bob:matrix.org/admin": &StatusError{URL: "test", StatusCode: http.StatusForbidden, Status: "403 Forbidden"},
+			},
+			wantOK:     map[string]bool{"health": true, "server_version": true, "client_versions": true, "whoami": true, "admin": false},
+			wantDetail: map[string]string{"admin": "access token does not belong to a server admin"},
+		},
+		{
+			name: "invalid token and unhealthy server",
+			responses: map[string][]byte{
+				"/health":                           []byte("starting"),
+				"/_synapse/admin/v1/server_version": []byte(`{"server_version": "1.120.0"}`),
+				"/_matrix/client/versions":          []byte(`{"versions": ["v1.11"]}`),
+			},
+			errors: map[string]error{
+				"/_matrix/client/v3/account/whoami": &StatusError{URL: "test", StatusCode: http.StatusUnauthorized, Status: "401 Unauthorized"},
+			},
+			wantOK: map[string]bool{"health": false, "server_version": true, "client_versions": true, "whoami": false, "admin": false},
+			wantDetail: map[string]string{
+				"whoami": "access token is invalid or expired",
+				"admin":  "skipped: token could not be verified",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			checks := CheckStatus(context.Background(), mock, logrus.New())
+			gotOK := make(map[string]bool)
+			for _, c := range checks {
+				gotOK[c.Name] = c.OK
+				if want, ok := tc.wantDetail[c.Name]; ok {
+					assert.Equal(t, want, c.Detail)
+				}
+			}
+			assert.Equal(t, tc.wantOK, gotOK)
+		})
+	}
+}
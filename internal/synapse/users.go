@@ -0,0 +1,186 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// User is an account as listed by the admin users API.
+type User struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayname"`
+	Admin       bool   `json:"admin"`
+	Deactivated bool   `json:"deactivated"`
+	IsGuest     bool   `json:"is_guest"`
+	UserType    string `json:"user_type"`
+	Locked      bool   `json:"locked"`
+	Suspended   bool   `json:"suspended"`
+}
+
+func (u User) Header() []string {
+	return []string{"User ID", "Display Name", "Admin", "Deactivated", "Locked", "Suspended", "User Type"}
+}
+
+func (u User) Row() []interface{} {
+	return []interface{}{u.Name, u.DisplayName, u.Admin, u.Deactivated, u.Locked, u.Suspended, u.UserType}
+}
+
+type usersResponse struct {
+	Users     []User      `json:"users"`
+	NextToken json.Number `json:"next_token"`
+	Total     int         `json:"total"`
+}
+
+const usersPageSize = 100
+
+// ListLocalUsers returns the IDs of every active, non-guest local user.
+func ListLocalUsers(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger) ([]string, error) {
+	users := make([]string, 0)
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(usersPageSize))
+	params.Set("guests", "false")
+	params.Set("deactivated", "false")
+
+	for {
+		logger.WithFields(logrus.Fields{
+			"event":  "list_local_users",
+			"params": params.Encode(),
+		}).Debug("Listing local users")
+		var resp usersResponse
+		if err := callJSON(ctx, client, "/_synapse/admin/v2/users?"+params.Encode(), &resp); err != nil {
+			return nil, err
+		}
+		for _, u := range resp.Users {
+			users = append(users, u.Name)
+		}
+		if resp.NextToken == "" {
+			return users, nil
+		}
+		params.Set("from", resp.NextToken.String())
+	}
+}
+
+// GetUser returns the admin view of a single account.
+func GetUser(ctx context.Context, client SynapseClientInterface, userID string) (User, error) {
+	var user User
+	err := callJSON(ctx, client, "/_synapse/admin/v2/users/"+userID, &user)
+	return user, err
+}
+
+type userIDResponse struct {
+	UserID string `json:"user_id"`
+}
+
+// FindUserByThreepid returns the ID of the user bound to a third-party
+// identifier, such as an email address (medium "email") or a phone number
+// (medium "msisdn"). A StatusError with code 404 means no user is bound to it.
+func FindUserByThreepid(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, medium string, address string) (string, error) {
+	logger.WithFields(logrus.Fields{
+		"event":  "find_user_by_threepid",
+		"medium": medium,
+	}).Debug("Looking up user by third-party ID")
+	var resp userIDResponse
+	err := callJSON(ctx, client, "/_synapse/admin/v1/threepid/"+url.PathEscape(medium)+"/users/"+url.PathEscape(address), &resp)
+	return resp.UserID, err
+}
+
+// FindUserByExternalID returns the ID of the user linked to an external ID
+// of an SSO auth provider. A StatusError with code 404 means no user is linked to it.
+func FindUserByExternalID(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, provider string, externalID string) (string, error) {
+	logger.WithFields(logrus.Fields{
+		"event":    "find_user_by_external_id",
+		"provider": provider,
+	}).Debug("Looking up user by external ID")
+	var resp userIDResponse
+	err := callJSON(ctx, client, "/_synapse/admin/v1/auth_providers/"+url.PathEscape(provider)+"/users/"+url.PathEscape(externalID), &resp)
+	return resp.UserID, err
+}
+
+// UserUpdate holds the account attributes to change. Nil fields are left untouched.
+type UserUpdate struct {
+	Locked *bool `json:"locked,omitempty"`
+}
+
+// UpdateUser changes the given attributes of an existing account. The admin
+// endpoint creates the account when it does not exist, so its existence is
+// checked first to keep a typo in the user ID from registering a new user.
+func UpdateUser(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, userID string, update UserUpdate) error {
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	if _, err := GetUser(ctx, client, userID); err != nil {
+		if IsNotFound(err) {
+			return fmt.Errorf("user %s does not exist: %w", userID, err)
+		}
+		return err
+	}
+	logger.WithFields(logrus.Fields{
+		"event": "update_user",
+		"user":  userID,
+	}).Debug("Updating user")
+	_, err = client.Call(ctx, "/_synapse/admin/v2/users/"+userID, "PUT", payload, false)
+	return err
+}
+
+// SetUserSuspended suspends or unsuspends a local account. Suspended users
+// can still read but cannot send messages, join rooms or change their profile.
+func SetUserSuspended(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger, userID string, suspend bool) error {
+	logger.WithFields(logrus.Fields{
+		"event":   "set_user_suspended",
+		"user":    userID,
+		"suspend": suspend,
+	}).Debug("Changing user suspension")
+	_, err := client.Call(ctx, "/_synapse/admin/v1/suspend/"+userID, "PUT", fmt.Appendf(nil, `{"suspend": %t}`, suspend), false)
+	return err
+}
+
+// ListRestrictedUsers returns the active local users that are suspended or locked.
+func ListRestrictedUsers(ctx context.Context, client SynapseClientInterface, logger *logrus.Logger) ([]User, error) {
+	users := make([]User, 0)
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(usersPageSize))
+	params.Set("deactivated", "false")
+	// Locked users are excluded from the list unless asked for
+	params.Set("locked", "true")
+
+	for {
+		logger.WithFields(logrus.Fields{
+			"event":  "list_restricted_users",
+			"params": params.Encode(),
+		}).Debug("Listing users")
+		var resp usersResponse
+		if err := callJSON(ctx, client, "/_synapse/admin/v2/users?"+params.Encode(), &resp); err != nil {
+			return nil, err
+		}
+		for _, u := range resp.Users {
+			if u.Locked || u.Suspended {
+				users = append(users, u)
+			}
+		}
+		if resp.NextToken == "" {
+			return users, nil
+		}
+		params.Set("from", resp.NextToken.String())
+	}
+}
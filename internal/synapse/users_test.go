@@ -0,0 +1,175 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package synapse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListLocalUsers(t *testing.T) {
+	cases := []struct {
+		name      string
+		responses map[string][]byte
+		errors    map[string]error
+		wantErr   bool
+		wantUsers []string
+	}{
+		{
+			name: "two pages",
+			responses: map[string][]byte{
+				"/_synapse/admin/v2/users?deactivated=false&guests=false&limit=100":          []byte(`{"users": [{"name": "@a:matrix.org"}], "next_token": "100", "total": 2}`),
+				"/_synapse/admin/v2/users?deactivated=false&from=100&guests=false&limit=100": []byte(`{"users": [{"name": "@b:matrix.org"}], "total": 2}`),
+			},
+			wantUsers: []string{"@a:matrix.org", "@b:matrix.org"},
+		},
+		{
+			name:    "request fails",
+			errors:  map[string]error{"/_synapse/admin/v2/users?deactivated=false&guests=false&limit=100": assert.AnError},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{Responses: tc.responses, Errors: tc.errors}
+			users, err := ListLocalUsers(context.Background(), mock, logrus.New())
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantUsers, users)
+		})
+	}
+}
+
+func TestFindUser(t *testing.T) {
+	notFound := &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"}
+	responses := map[string][]byte{
+		"/_synapse/admin/v1/threepid/email/users/alice@example.org":        []byte(`{"user_id": "@alice:matrix.org"}`),
+		"/_synapse/admin/v1/auth_providers/oidc/users/a%2Fb":               []byte(`{"user_id": "@bob:matrix.org"}`),
+		"/_synapse/admin/v1/threepid/email/users/bob@example.org":          nil,
+		"/_synapse/admin/v1/auth_providers/oidc-github/users/unknown-user": nil,
+	}
+	errors := map[string]error{
+		"/_synapse/admin/v1/threepid/email/users/bob@example.org":          notFound,
+		"/_synapse/admin/v1/auth_providers/oidc-github/users/unknown-user": notFound,
+	}
+	mock := &MockClient{Responses: responses, Errors: errors}
+	logger := logrus.New()
+
+	user, err := FindUserByThreepid(context.Background(), mock, logger, "email", "alice@example.org")
+	assert.NoError(t, err)
+	assert.Equal(t, "@alice:matrix.org", user)
+
+	_, err = FindUserByThreepid(context.Background(), mock, logger, "email", "bob@example.org")
+	assert.True(t, IsNotFound(err))
+
+	user, err = FindUserByExternalID(context.Background(), mock, logger, "oidc", "a/b")
+	assert.NoError(t, err)
+	assert.Equal(t, "@bob:matrix.org", user)
+
+	_, err = FindUserByExternalID(context.Background(), mock, logger, "oidc-github", "unknown-user")
+	assert.True(t, IsNotFound(err))
+}
+
+func TestUpdateUser(t *testing.T) {
+	locked := true
+	mock := &MockClient{Responses: map[string][]byte{"/_synapse/admin/v2/users/@a:matrix.org": []byte(`{}`)}}
+	err := UpdateUser(context.Background(), mock, logrus.New(), "@a:matrix.org", UserUpdate{Locked: &locked})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"locked": true}`, string(mock.Payloads["/_synapse/admin/v2/users/@a:matrix.org"]))
+
+	unlocked := false
+	err = UpdateUser(context.Background(), mock, logrus.New(), "@a:matrix.org", UserUpdate{Locked: &unlocked})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"locked": false}`, string(mock.Payloads["/_synapse/admin/v2/users/@a:matrix.org"]))
+
+	// A missing user must not be created by the update
+	missing := &MockClient{Errors: map[string]error{
+		"/_synapse/admin/v2/users/@typo:matrix.org": &StatusError{URL: "test", StatusCode: http.StatusNotFound, Status: "404 Not Found"},
+	}}
+	missingCalls := &methodRecorder{SynapseClientInterface: missing}
+	err = UpdateUser(context.Background(), missingCalls, logrus.New(), "@typo:matrix.org", UserUpdate{Locked: &locked})
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, []string{"GET"}, missingCalls.methods)
+}
+
+// methodRecorder records the HTTP methods of the calls passed to the wrapped client.
+type methodRecorder struct {
+	SynapseClientInterface
+	methods []string
+}
+
+func (m *methodRecorder) Call(ctx context.Context, path string, method string, payload []byte, retry bool) ([]byte, error) {
+	m.methods = append(m.methods, method)
+	return m.SynapseClientInterface.Call(ctx, path, method, payload, retry)
+}
+
+func TestSetUserSuspended(t *testing.T) {
+	cases := []struct {
+		name        string
+		suspend     bool
+		errors      map[string]error
+		wantPayload string
+		wantErr     bool
+	}{
+		{name: "suspend", suspend: true, wantPayload: `{"suspend": true}`},
+		{name: "unsuspend", suspend: false, wantPayload: `{"suspend": false}`},
+		{
+			name:    "remote user",
+			suspend: true,
+			errors:  map[string]error{"/_synapse/admin/v1/suspend/@a:matrix.org": &StatusError{URL: "test", StatusCode: http.StatusBadRequest, Status: "400 Bad Request"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &MockClient{
+				Responses: map[string][]byte{"/_synapse/admin/v1/suspend/@a:matrix.org": []byte(`{}`)},
+				Errors:    tc.errors,
+			}
+			err := SetUserSuspended(context.Background(), mock, logrus.New(), "@a:matrix.org", tc.suspend)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.wantPayload, string(mock.Payloads["/_synapse/admin/v1/suspend/@a:matrix.org"]))
+		})
+	}
+}
+
+func TestListRestrictedUsers(t *testing.T) {
+	mock := &MockClient{Responses: map[string][]byte{
+		"/_synapse/admin/v2/users?deactivated=false&limit=100&locked=true": []byte(`{"users": [
+			{"name": "@a:matrix.org", "locked": true},
+			{"name": "@b:matrix.org"}
+		], "next_token": "100"}`),
+		"/_synapse/admin/v2/users?deactivated=false&from=100&limit=100&locked=true": []byte(`{"users": [
+			{"name": "@c:matrix.org", "suspended": true}
+		]}`),
+	}}
+	users, err := ListRestrictedUsers(context.Background(), mock, logrus.New())
+	assert.NoError(t, err)
+	assert.Equal(t, []User{{Name: "@a:matrix.org", Locked: true}, {Name: "@c:matrix.org", Suspended: true}}, users)
+}
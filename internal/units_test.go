@@ -0,0 +1,138 @@
+/*
+Copyright © 2026 Amanda Hager Lopes de Andrade Katz amandahla@gmail.com
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAge(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "30d", want: 30 * 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "36h", want: 36 * time.Hour},
+		{input: "1.5d", want: 36 * time.Hour},
+		{input: "-1d", wantErr: true},
+		{input: "abc", wantErr: true},
+		{input: "d", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseAge(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestHumanBytes(t *testing.T) {
+	cases := []struct {
+		input int64
+		want  string
+	}{
+		{input: 0, want: "0 B"},
+		{input: 1023, want: "1023 B"},
+		{input: 1536, want: "1.5 KB"},
+		{input: 10 * 1024 * 1024, want: "10.0 MB"},
+		{input: 3 * 1024 * 1024 * 1024, want: "3.0 GB"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, HumanBytes(tc.input))
+		})
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{input: "1024", want: 1024},
+		{input: "10MB", want: 10 * 1024 * 1024},
+		{input: "1.5kb", want: 1536},
+		{input: "2 GB", want: 2 * 1024 * 1024 * 1024},
+		{input: "MB", wantErr: true},
+		{input: "-5MB", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseSize(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParsePastTime(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "2026-09-01", want: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2026-09-01T10:00:00Z", want: time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)},
+		{input: "7d", want: now.Add(-7 * 24 * time.Hour)},
+		{input: "yesterday", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParsePastTime(tc.input, now)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tc.want.Equal(got))
+		})
+	}
+}
+
+func TestParseFutureTime(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	got, err := ParseFutureTime("7d", now)
+	assert.NoError(t, err)
+	assert.True(t, now.Add(7*24*time.Hour).Equal(got))
+
+	got, err = ParseFutureTime("2026-12-01", now)
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC).Equal(got))
+
+	_, err = ParseFutureTime("soon", now)
+	assert.Error(t, err)
+}